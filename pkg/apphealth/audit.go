@@ -0,0 +1,78 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// TransitionEvent describes a change in the app's health status.
+// The reason and source of the transition are in To.
+type TransitionEvent struct {
+	// ID identifies the transition; IDs are assigned in increasing order starting at 1.
+	ID           uint64
+	At           time.Time
+	From         *Status
+	To           *Status
+	FailureCount int32
+}
+
+type transitionIDKey struct{}
+
+func withTransitionID(ctx context.Context, id uint64) context.Context {
+	return context.WithValue(ctx, transitionIDKey{}, id)
+}
+
+// TransitionIDFromContext returns the ID of the transition from the context passed to a ChangeCallback.
+// Returns false if the context doesn't carry a transition ID.
+func TransitionIDFromContext(ctx context.Context) (uint64, bool) {
+	id, ok := ctx.Value(transitionIDKey{}).(uint64)
+	return id, ok
+}
+
+// AuditSink receives a record of every health transition.
+// Record is invoked synchronously when the transition happens, so implementations that perform I/O should buffer the events.
+type AuditSink interface {
+	Record(event TransitionEvent)
+}
+
+// SetAuditSink sets the sink that records health transitions.
+// Passing nil disables auditing.
+func (h *AppHealth) SetAuditSink(sink AuditSink) {
+	h.auditSink.Store(&sink)
+}
+
+// MemoryAuditSink is an AuditSink that keeps all events in memory.
+// Events are never evicted.
+type MemoryAuditSink struct {
+	lock   sync.Mutex
+	events []TransitionEvent
+}
+
+// Record implements AuditSink.
+func (m *MemoryAuditSink) Record(event TransitionEvent) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.events = append(m.events, event)
+}
+
+// Events returns a copy of all recorded events, in the order they were recorded.
+func (m *MemoryAuditSink) Events() []TransitionEvent {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return slices.Clone(m.events)
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	"github.com/dapr/dapr/pkg/config"
+)
+
+func TestAuditSink(t *testing.T) {
+	h := New(config.AppHealthConfig{
+		Threshold: 2,
+	}, nil)
+	clock := clocktesting.NewFakeClock(time.Unix(1700000000, 0))
+	h.clock = clock
+
+	sink := &MemoryAuditSink{}
+	h.SetAuditSink(sink)
+
+	healthy := NewStatus(true, nil)
+	healthy.Source = StatusSourceProbe
+	h.setResult(t.Context(), healthy)
+
+	clock.Step(time.Second)
+	reason := "down"
+	unhealthy := NewStatus(false, &reason)
+	unhealthy.Source = StatusSourceReport
+	h.setResult(t.Context(), unhealthy)
+	// Not a transition until the threshold is crossed
+	require.Len(t, sink.Events(), 1)
+	h.setResult(t.Context(), unhealthy)
+	// Further failures are not transitions
+	h.setResult(t.Context(), unhealthy)
+
+	events := sink.Events()
+	require.Len(t, events, 2)
+
+	assert.Equal(t, uint64(1), events[0].ID)
+	assert.Equal(t, uint64(2), events[1].ID)
+	assert.Equal(t, uint64(2), h.Snapshot().LastTransitionID)
+
+	assert.Equal(t, time.Unix(1700000000, 0), events[0].At)
+	assert.False(t, events[0].From.IsHealthy)
+	assert.Equal(t, StatusSourceUnknown, events[0].From.Source)
+	assert.Same(t, healthy, events[0].To)
+	assert.Equal(t, int32(0), events[0].FailureCount)
+
+	assert.Equal(t, time.Unix(1700000001, 0), events[1].At)
+	assert.True(t, events[1].From.IsHealthy)
+	assert.Same(t, unhealthy, events[1].To)
+	assert.Equal(t, StatusSourceReport, events[1].To.Source)
+	assert.Equal(t, "down", *events[1].To.Reason)
+	assert.Equal(t, int32(2), events[1].FailureCount)
+
+	// Disabling the sink stops recording
+	h.SetAuditSink(nil)
+	h.setResult(t.Context(), healthy)
+	assert.Len(t, sink.Events(), 2)
+	require.NoError(t, h.Close())
+}
+
+func TestTransitionIDFromContext(t *testing.T) {
+	h := New(config.AppHealthConfig{
+		Threshold: 1,
+	}, nil)
+
+	ids := make(chan uint64, 2)
+	h.OnHealthChange(func(ctx context.Context, status *Status) {
+		id, ok := TransitionIDFromContext(ctx)
+		assert.True(t, ok)
+		ids <- id
+	})
+
+	h.setResult(t.Context(), NewStatus(true, nil))
+	assert.Equal(t, uint64(1), <-ids)
+	h.setResult(t.Context(), NewStatus(false, nil))
+	assert.Equal(t, uint64(2), <-ids)
+
+	history := h.History()
+	require.Len(t, history, 2)
+	assert.Equal(t, uint64(2), history[1].ID)
+
+	_, ok := TransitionIDFromContext(t.Context())
+	assert.False(t, ok)
+	require.NoError(t, h.Close())
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"sync"
+)
+
+// ReadyBarrier is a latch that opens the first time the app becomes healthy.
+// Once open, it stays open even if the app becomes unhealthy again, until Reset is called.
+type ReadyBarrier struct {
+	lock  sync.Mutex
+	ch    chan struct{}
+	ready bool
+}
+
+// ReadyBarrier returns the barrier that opens when the app becomes healthy.
+// All callers share the same barrier.
+func (h *AppHealth) ReadyBarrier() *ReadyBarrier {
+	h.barrierOnce.Do(func() {
+		b := &ReadyBarrier{
+			ch: make(chan struct{}),
+		}
+		h.barrier.Store(b)
+		// Transitions that happened before the barrier was stored aren't delivered to it
+		if h.GetStatus().IsHealthy {
+			b.open()
+		}
+	})
+	return h.barrier.Load()
+}
+
+// Wait blocks until the barrier is open or the context is canceled.
+// Returns the context's error if it was canceled first.
+func (b *ReadyBarrier) Wait(ctx context.Context) error {
+	b.lock.Lock()
+	ch := b.ch
+	b.lock.Unlock()
+
+	select {
+	case <-ch:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Ready returns true if the barrier is open.
+func (b *ReadyBarrier) Ready() bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.ready
+}
+
+// Reset closes the barrier again, so it opens at the next transition to healthy.
+func (b *ReadyBarrier) Reset() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.ready {
+		b.ch = make(chan struct{})
+		b.ready = false
+	}
+}
+
+func (b *ReadyBarrier) open() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if !b.ready {
+		close(b.ch)
+		b.ready = true
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/dapr/pkg/config"
+)
+
+func TestReadyBarrier(t *testing.T) {
+	t.Run("opens on first healthy and stays open", func(t *testing.T) {
+		h := New(config.AppHealthConfig{
+			Threshold: 1,
+		}, nil)
+		t.Cleanup(func() { h.Close() })
+
+		b := h.ReadyBarrier()
+		assert.Same(t, b, h.ReadyBarrier())
+		assert.False(t, b.Ready())
+
+		ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+		defer cancel()
+		require.ErrorIs(t, b.Wait(ctx), context.DeadlineExceeded)
+
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- b.Wait(t.Context())
+		}()
+
+		h.setResult(t.Context(), NewStatus(true, nil))
+		select {
+		case err := <-errCh:
+			require.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("barrier did not open")
+		}
+		assert.True(t, b.Ready())
+
+		h.setResult(t.Context(), NewStatus(false, nil))
+		assert.True(t, b.Ready())
+		require.NoError(t, b.Wait(t.Context()))
+
+		b.Reset()
+		assert.False(t, b.Ready())
+		h.setResult(t.Context(), NewStatus(true, nil))
+		assert.True(t, b.Ready())
+	})
+
+	t.Run("already healthy", func(t *testing.T) {
+		h := New(config.AppHealthConfig{
+			Threshold: 1,
+		}, nil)
+		t.Cleanup(func() { h.Close() })
+
+		h.setResult(t.Context(), NewStatus(true, nil))
+		b := h.ReadyBarrier()
+		assert.True(t, b.Ready())
+		require.NoError(t, b.Wait(t.Context()))
+	})
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+// BreakerBridge connects app health to a circuit breaker, such as the ones defined by resiliency policies, without this package depending on them.
+// The coupling goes both ways: health transitions drive the breaker, and the breaker can pause probes.
+// Implementations must be safe for concurrent use and must not block.
+type BreakerBridge interface {
+	// OnHealthTransition is invoked synchronously on every health transition.
+	// Implementations typically open the breaker when the app becomes unhealthy and close it when it recovers.
+	OnHealthTransition(healthy bool)
+	// AllowProbe is consulted before each scheduled probe; probes are skipped while it returns false.
+	// Implementations should return true once the breaker is half-open, otherwise the app can never be detected as healthy again.
+	AllowProbe() bool
+}
+
+// SetBreakerBridge sets the bridge to a circuit breaker.
+// Passing nil removes the bridge.
+func (h *AppHealth) SetBreakerBridge(b BreakerBridge) {
+	h.breaker.Store(&b)
+}
+
+func (h *AppHealth) breakerBridge() BreakerBridge {
+	b := h.breaker.Load()
+	if b == nil {
+		return nil
+	}
+	return *b
+}
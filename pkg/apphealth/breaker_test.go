@@ -0,0 +1,88 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	"github.com/dapr/dapr/pkg/config"
+)
+
+type testBreaker struct {
+	lock        sync.Mutex
+	transitions []bool
+	allow       atomic.Bool
+}
+
+func (b *testBreaker) OnHealthTransition(healthy bool) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.transitions = append(b.transitions, healthy)
+}
+
+func (b *testBreaker) AllowProbe() bool {
+	return b.allow.Load()
+}
+
+func TestAppHealth_BreakerBridge(t *testing.T) {
+	var probeCalls atomic.Int32
+	h := New(config.AppHealthConfig{
+		ProbeInterval: time.Second,
+		ProbeTimeout:  time.Second,
+		Threshold:     1,
+	}, func(context.Context) (*Status, error) {
+		probeCalls.Add(1)
+		return NewStatus(true, nil), nil
+	})
+	clock := clocktesting.NewFakeClock(time.Now())
+	h.clock = clock
+	t.Cleanup(func() { h.Close() })
+
+	b := &testBreaker{}
+	h.SetBreakerBridge(b)
+
+	// Transitions drive the breaker
+	h.setResult(t.Context(), NewStatus(true, nil))
+	h.setResult(t.Context(), NewStatus(false, nil))
+	b.lock.Lock()
+	assert.Equal(t, []bool{true, false}, b.transitions)
+	b.lock.Unlock()
+
+	// Probes are skipped while the breaker doesn't allow them
+	require.NoError(t, h.StartProbes(t.Context()))
+	assert.Eventually(t, clock.HasWaiters, time.Second, time.Microsecond)
+	clock.Step(time.Second)
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, int32(0), probeCalls.Load())
+
+	b.allow.Store(true)
+	clock.Step(time.Second)
+	assert.Eventually(t, func() bool {
+		return probeCalls.Load() == 1 && h.IsHealthy()
+	}, time.Second, time.Microsecond)
+
+	h.SetBreakerBridge(nil)
+	h.setResult(t.Context(), NewStatus(false, nil))
+	b.lock.Lock()
+	assert.Len(t, b.transitions, 3)
+	b.lock.Unlock()
+}
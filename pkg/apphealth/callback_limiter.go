@@ -0,0 +1,124 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+)
+
+// callbackLimiter is a token bucket that limits how often the change callback is invoked.
+// Transitions that arrive while there are no tokens are coalesced, so once a token is available the callback is invoked with the latest status only.
+type callbackLimiter struct {
+	h     *AppHealth
+	rate  float64
+	burst float64
+
+	lock       sync.Mutex
+	tokens     float64
+	last       time.Time
+	pending    *Status
+	pendingCtx context.Context
+	timer      clock.Timer
+	stopped    bool
+}
+
+func newCallbackLimiter(h *AppHealth, rate float64) *callbackLimiter {
+	burst := max(rate, 1)
+	return &callbackLimiter{
+		h:      h,
+		rate:   rate,
+		burst:  burst,
+		tokens: burst,
+	}
+}
+
+// refill adds the tokens accumulated since the last refill.
+// Must be invoked with the lock held.
+func (l *callbackLimiter) refill() {
+	now := l.h.clock.Now()
+	if !l.last.IsZero() {
+		if elapsed := now.Sub(l.last); elapsed > 0 {
+			l.tokens = min(l.burst, l.tokens+elapsed.Seconds()*l.rate)
+		}
+	}
+	l.last = now
+}
+
+func (l *callbackLimiter) notify(ctx context.Context, status *Status) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.stopped {
+		return
+	}
+
+	// If there's a flush already scheduled, just replace the status it will deliver
+	if l.timer != nil {
+		l.pending = status
+		l.pendingCtx = ctx
+		return
+	}
+
+	l.refill()
+	if l.tokens >= 1 {
+		l.tokens--
+		l.h.dispatchChange(ctx, status)
+		return
+	}
+
+	log.Debug("Rate limiting app health change callback")
+	l.pending = status
+	l.pendingCtx = ctx
+	wait := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+	l.h.wg.Add(1)
+	l.timer = l.h.clock.AfterFunc(wait, func() {
+		// Run in a separate goroutine since some clocks invoke the function while holding their own locks
+		go l.flush()
+	})
+}
+
+func (l *callbackLimiter) flush() {
+	defer l.h.wg.Done()
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.stopped || l.pending == nil {
+		return
+	}
+
+	l.refill()
+	l.tokens = max(l.tokens-1, 0)
+	l.h.dispatchChange(l.pendingCtx, l.pending)
+	l.pending = nil
+	l.pendingCtx = nil
+	l.timer = nil
+}
+
+func (l *callbackLimiter) stop() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.stopped = true
+	if l.timer != nil && l.timer.Stop() {
+		l.h.wg.Done()
+	}
+	l.timer = nil
+	l.pending = nil
+	l.pendingCtx = nil
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	"github.com/dapr/dapr/pkg/config"
+)
+
+func TestCallbackLimiter(t *testing.T) {
+	h := New(config.AppHealthConfig{
+		Threshold:             1,
+		MaxCallbacksPerSecond: 1,
+	}, nil)
+	clock := clocktesting.NewFakeClock(time.Now())
+	h.clock = clock
+
+	calls := make(chan bool, 10)
+	h.OnHealthChange(func(ctx context.Context, status *Status) {
+		calls <- status.IsHealthy
+	})
+
+	// The first transition consumes the only token
+	h.setResult(t.Context(), NewStatus(true, nil))
+	select {
+	case v := <-calls:
+		assert.True(t, v)
+	case <-time.After(time.Second):
+		require.Fail(t, "callback not invoked")
+	}
+
+	// Subsequent transitions are suppressed and coalesced
+	h.setResult(t.Context(), NewStatus(false, nil))
+	h.setResult(t.Context(), NewStatus(true, nil))
+	h.setResult(t.Context(), NewStatus(false, nil))
+	assert.Eventually(t, clock.HasWaiters, time.Second, time.Microsecond)
+	select {
+	case <-calls:
+		require.Fail(t, "callback should have been rate limited")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	// Once a token is available, only the latest state is delivered
+	clock.Step(time.Second)
+	select {
+	case v := <-calls:
+		assert.False(t, v)
+	case <-time.After(time.Second):
+		require.Fail(t, "callback not invoked")
+	}
+	select {
+	case <-calls:
+		require.Fail(t, "only one callback expected")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	// Close with a pending flush returns promptly and drops it
+	h.setResult(t.Context(), NewStatus(true, nil))
+	assert.Eventually(t, clock.HasWaiters, time.Second, time.Microsecond)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		h.Close()
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		require.Fail(t, "Close didn't return in time")
+	}
+	assert.Empty(t, calls)
+}
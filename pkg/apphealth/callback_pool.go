@@ -0,0 +1,109 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"sync"
+)
+
+// callbackPoolQueueFactor is the number of invocations that can be queued for each goroutine of the pool.
+const callbackPoolQueueFactor = 16
+
+// callbackPool bounds the number of goroutines running the change callback.
+// When all goroutines are busy, invocations are either queued, to be run by the next goroutine that becomes free, or dropped.
+// The queue holds up to callbackPoolQueueFactor invocations per goroutine; when it's full, the oldest one is dropped.
+type callbackPool struct {
+	h    *AppHealth
+	max  int
+	drop bool
+
+	lock    sync.Mutex
+	running int
+	pending []pendingCallback
+	// dropped is the number of invocations dropped because the queue was full.
+	dropped uint64
+}
+
+type pendingCallback struct {
+	ctx    context.Context
+	cb     ChangeCallback
+	status *Status
+}
+
+func newCallbackPool(h *AppHealth, maxGoroutines int, drop bool) *callbackPool {
+	return &callbackPool{
+		h:    h,
+		max:  maxGoroutines,
+		drop: drop,
+	}
+}
+
+// dispatch runs the callback on a free goroutine, or queues or drops it if there's none.
+func (p *callbackPool) dispatch(ctx context.Context, cb ChangeCallback, status *Status) {
+	item := pendingCallback{ctx: ctx, cb: cb, status: status}
+
+	p.lock.Lock()
+	if p.running >= p.max {
+		if p.drop {
+			p.lock.Unlock()
+			log.Warnf("Dropping app health change callback because %d callbacks are already running", p.max)
+			return
+		}
+		// Newer invocations are more relevant, so the oldest one makes room
+		if len(p.pending) >= p.max*callbackPoolQueueFactor {
+			p.pending[0] = pendingCallback{}
+			p.pending = p.pending[1:]
+			p.dropped++
+			log.Warnf("Dropping the oldest queued app health change callback because %d callbacks are queued", p.max*callbackPoolQueueFactor)
+		}
+		p.pending = append(p.pending, item)
+		n := len(p.pending)
+		p.lock.Unlock()
+		log.Warnf("Queueing app health change callback because %d callbacks are already running; %d queued", p.max, n)
+		return
+	}
+	p.running++
+	p.lock.Unlock()
+
+	p.h.wg.Add(1)
+	go func() {
+		defer p.h.wg.Done()
+		p.run(item)
+	}()
+}
+
+// invoke runs a single callback, recovering from panics so the remaining queued callbacks still run.
+func (p *callbackPool) invoke(item pendingCallback) {
+	defer p.h.recoverCallback()
+	item.cb(item.ctx, item.status)
+}
+
+// run invokes the callback, then the queued ones until the queue is empty.
+func (p *callbackPool) run(item pendingCallback) {
+	for {
+		p.invoke(item)
+
+		p.lock.Lock()
+		if len(p.pending) == 0 {
+			p.running--
+			p.lock.Unlock()
+			return
+		}
+		item = p.pending[0]
+		p.pending[0] = pendingCallback{}
+		p.pending = p.pending[1:]
+		p.lock.Unlock()
+	}
+}
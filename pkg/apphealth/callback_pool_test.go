@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/dapr/pkg/config"
+)
+
+func TestAppHealth_MaxCallbackGoroutines(t *testing.T) {
+	run := func(t *testing.T, drop bool) (calls []bool, maxRunning int32) {
+		h := New(config.AppHealthConfig{
+			Threshold:                  1,
+			MaxCallbackGoroutines:      1,
+			DropCallbacksWhenSaturated: drop,
+		}, nil)
+
+		release := make(chan struct{})
+		var (
+			lock    sync.Mutex
+			running atomic.Int32
+			peak    atomic.Int32
+		)
+		h.OnHealthChange(func(_ context.Context, status *Status) {
+			n := running.Add(1)
+			defer running.Add(-1)
+			if n > peak.Load() {
+				peak.Store(n)
+			}
+			<-release
+			lock.Lock()
+			defer lock.Unlock()
+			calls = append(calls, status.IsHealthy)
+		})
+
+		h.setResult(t.Context(), NewStatus(true, nil))
+		h.setResult(t.Context(), NewStatus(false, nil))
+		h.setResult(t.Context(), NewStatus(true, nil))
+
+		closed := make(chan struct{})
+		go func() {
+			defer close(closed)
+			// Close waits for the running and queued callbacks
+			h.Close()
+		}()
+		select {
+		case <-closed:
+			require.Fail(t, "Close returned while a callback was running")
+		case <-time.After(10 * time.Millisecond):
+		}
+		close(release)
+		<-closed
+
+		lock.Lock()
+		defer lock.Unlock()
+		return calls, peak.Load()
+	}
+
+	t.Run("queue", func(t *testing.T) {
+		calls, peak := run(t, false)
+		// Queued callbacks run in order
+		assert.Equal(t, []bool{true, false, true}, calls)
+		assert.Equal(t, int32(1), peak)
+	})
+
+	t.Run("drop", func(t *testing.T) {
+		calls, peak := run(t, true)
+		assert.Equal(t, []bool{true}, calls)
+		assert.Equal(t, int32(1), peak)
+	})
+}
+
+func TestCallbackPoolQueueLimit(t *testing.T) {
+	h := New(config.AppHealthConfig{Threshold: 1}, nil)
+	p := newCallbackPool(h, 1, false)
+
+	release := make(chan struct{})
+	var (
+		lock  sync.Mutex
+		calls []int
+	)
+	cb := func(ctx context.Context, status *Status) {
+		<-release
+		lock.Lock()
+		defer lock.Unlock()
+		n, _ := strconv.Atoi(*status.Reason)
+		calls = append(calls, n)
+	}
+
+	const n = callbackPoolQueueFactor + 4
+	for i := range n {
+		reason := strconv.Itoa(i)
+		p.dispatch(t.Context(), cb, NewStatus(true, &reason))
+	}
+
+	p.lock.Lock()
+	assert.Len(t, p.pending, callbackPoolQueueFactor)
+	assert.Equal(t, uint64(3), p.dropped)
+	p.lock.Unlock()
+
+	close(release)
+	h.wg.Wait()
+
+	// The running callback completes, and the oldest queued ones were dropped
+	expected := []int{0}
+	for i := 4; i < n; i++ {
+		expected = append(expected, i)
+	}
+	lock.Lock()
+	defer lock.Unlock()
+	assert.Equal(t, expected, calls)
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CombinePolicy selects how CombinedStatus merges the liveness and readiness of the app into a single status.
+type CombinePolicy uint8
+
+const (
+	// CombineBothHealthy reports the app as healthy only when both liveness and readiness are healthy.
+	CombineBothHealthy CombinePolicy = iota
+	// CombineLivenessDominant reports the liveness status.
+	CombineLivenessDominant
+	// CombineReadinessDominant reports the readiness status.
+	CombineReadinessDominant
+)
+
+var combinePolicyNames = map[CombinePolicy]string{
+	CombineBothHealthy:       "both-healthy",
+	CombineLivenessDominant:  "liveness-dominant",
+	CombineReadinessDominant: "readiness-dominant",
+}
+
+func (p CombinePolicy) String() string {
+	if n, ok := combinePolicyNames[p]; ok {
+		return n
+	}
+	return "unknown"
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (p CombinePolicy) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (p *CombinePolicy) UnmarshalText(text []byte) error {
+	name := string(text)
+	for v, n := range combinePolicyNames {
+		if n == name {
+			*p = v
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid combine policy: %q", name)
+}
+
+// CombinedStatus merges a liveness and a readiness status into a single status according to the policy.
+// The reason of an unhealthy result is prefixed with the axis that caused it, for example "readiness: ...".
+// A nil status is treated as unhealthy.
+func CombinedStatus(policy CombinePolicy, liveness, readiness *Status) *Status {
+	switch policy {
+	case CombineLivenessDominant:
+		return axisStatus("liveness", liveness)
+	case CombineReadinessDominant:
+		return axisStatus("readiness", readiness)
+	default:
+		live := axisStatus("liveness", liveness)
+		ready := axisStatus("readiness", readiness)
+		var reasons []string
+		for _, s := range []*Status{live, ready} {
+			if !s.IsHealthy {
+				reasons = append(reasons, *s.Reason)
+			}
+		}
+		if len(reasons) == 0 {
+			return NewStatus(true, nil)
+		}
+		reason := strings.Join(reasons, "; ")
+		return NewStatus(false, &reason)
+	}
+}
+
+// axisStatus returns a copy of status with the reason prefixed with the axis name.
+func axisStatus(axis string, status *Status) *Status {
+	if status == nil {
+		reason := axis + ": no status"
+		return NewStatus(false, &reason)
+	}
+
+	res := *status
+	if res.IsHealthy {
+		return &res
+	}
+	reason := axis + ": unhealthy"
+	if status.Reason != nil {
+		reason = axis + ": " + *status.Reason
+	}
+	res.Reason = &reason
+	return &res
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCombinePolicyText(t *testing.T) {
+	for _, p := range []CombinePolicy{CombineBothHealthy, CombineLivenessDominant, CombineReadinessDominant} {
+		t.Run(p.String(), func(t *testing.T) {
+			text, err := p.MarshalText()
+			require.NoError(t, err)
+
+			var parsed CombinePolicy
+			require.NoError(t, parsed.UnmarshalText(text))
+			assert.Equal(t, p, parsed)
+		})
+	}
+
+	assert.Equal(t, "unknown", CombinePolicy(200).String())
+	var parsed CombinePolicy
+	require.Error(t, parsed.UnmarshalText([]byte("unknown")))
+}
+
+func TestCombinedStatus(t *testing.T) {
+	status := func(healthy bool) *Status {
+		if healthy {
+			return NewStatus(true, nil)
+		}
+		reason := "down"
+		return NewStatus(false, &reason)
+	}
+
+	tests := []struct {
+		policy    CombinePolicy
+		liveness  bool
+		readiness bool
+		healthy   bool
+		reason    string
+	}{
+		{CombineBothHealthy, true, true, true, ""},
+		{CombineBothHealthy, true, false, false, "readiness: down"},
+		{CombineBothHealthy, false, true, false, "liveness: down"},
+		{CombineBothHealthy, false, false, false, "liveness: down; readiness: down"},
+		{CombineLivenessDominant, true, true, true, ""},
+		{CombineLivenessDominant, true, false, true, ""},
+		{CombineLivenessDominant, false, true, false, "liveness: down"},
+		{CombineLivenessDominant, false, false, false, "liveness: down"},
+		{CombineReadinessDominant, true, true, true, ""},
+		{CombineReadinessDominant, true, false, false, "readiness: down"},
+		{CombineReadinessDominant, false, true, true, ""},
+		{CombineReadinessDominant, false, false, false, "readiness: down"},
+	}
+
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("%s/liveness=%v/readiness=%v", tc.policy, tc.liveness, tc.readiness), func(t *testing.T) {
+			res := CombinedStatus(tc.policy, status(tc.liveness), status(tc.readiness))
+			assert.Equal(t, tc.healthy, res.IsHealthy)
+			if tc.healthy {
+				assert.Nil(t, res.Reason)
+			} else {
+				require.NotNil(t, res.Reason)
+				assert.Equal(t, tc.reason, *res.Reason)
+			}
+		})
+	}
+
+	t.Run("nil status is unhealthy", func(t *testing.T) {
+		res := CombinedStatus(CombineBothHealthy, nil, status(true))
+		assert.False(t, res.IsHealthy)
+		assert.Equal(t, "liveness: no status", *res.Reason)
+	})
+
+	t.Run("inputs are not modified", func(t *testing.T) {
+		live := status(false)
+		CombinedStatus(CombineLivenessDominant, live, nil)
+		assert.Equal(t, "down", *live.Reason)
+	})
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"slices"
+
+	"github.com/dapr/dapr/pkg/config"
+	"github.com/dapr/kit/ptr"
+)
+
+// EffectiveConfig returns the configuration currently in effect, with defaults resolved to the values that are used.
+// ProbeInterval is the interval the probe loop currently uses, including a temporary override set with OverrideInterval and the clamping to MinProbeInterval.
+// The configuration can't change after New, and overrides are read atomically like the probe loop does, so the result is consistent.
+func (h *AppHealth) EffectiveConfig() config.AppHealthConfig {
+	c := h.config
+	c.ProbeInterval = h.probeInterval()
+	if c.MinProbeInterval == 0 {
+		c.MinProbeInterval = config.AppHealthConfigDefaultMinProbeInterval
+	}
+	c.ReportsOverrideProbes = ptr.Of(h.reportsOverrideProbes())
+	c.FireInitialTransition = ptr.Of(h.fireInitialTransition())
+	if c.HistorySize == 0 {
+		c.HistorySize = config.AppHealthConfigDefaultHistorySize
+	}
+	if c.MaxReasonLength == 0 {
+		c.MaxReasonLength = config.AppHealthConfigDefaultMaxReasonLength
+	}
+	if c.ConfirmationProbes > 0 {
+		c.ConfirmationInterval = h.confirmationInterval()
+	}
+	if c.StartupBurst > 0 {
+		c.StartupBurstInterval = h.loopInterval(c.StartupBurst)
+	}
+	c.MaintenanceSchedule = slices.Clone(c.MaintenanceSchedule)
+	return c
+}
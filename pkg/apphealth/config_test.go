@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	"github.com/dapr/dapr/pkg/config"
+	"github.com/dapr/kit/ptr"
+)
+
+func TestAppHealth_EffectiveConfig(t *testing.T) {
+	t.Run("defaults are resolved", func(t *testing.T) {
+		h := New(config.AppHealthConfig{
+			ProbeInterval:      time.Second,
+			ProbeTimeout:       500 * time.Millisecond,
+			Threshold:          3,
+			ConfirmationProbes: 4,
+			StartupBurst:       2,
+		}, nil)
+		t.Cleanup(func() { h.Close() })
+
+		c := h.EffectiveConfig()
+		assert.Equal(t, time.Second, c.ProbeInterval)
+		assert.Equal(t, 500*time.Millisecond, c.ProbeTimeout)
+		assert.Equal(t, int32(3), c.Threshold)
+		assert.Equal(t, config.AppHealthConfigDefaultMinProbeInterval, c.MinProbeInterval)
+		assert.Equal(t, ptr.Of(true), c.ReportsOverrideProbes)
+		assert.Equal(t, ptr.Of(true), c.FireInitialTransition)
+		assert.Equal(t, config.AppHealthConfigDefaultHistorySize, c.HistorySize)
+		assert.Equal(t, config.AppHealthConfigDefaultMaxReasonLength, c.MaxReasonLength)
+		assert.Equal(t, 250*time.Millisecond, c.ConfirmationInterval)
+		assert.Equal(t, config.AppHealthConfigDefaultStartupBurstInterval, c.StartupBurstInterval)
+	})
+
+	t.Run("explicit values are kept", func(t *testing.T) {
+		h := New(config.AppHealthConfig{
+			ProbeInterval:         time.Second,
+			MinProbeInterval:      -1,
+			ReportsOverrideProbes: ptr.Of(false),
+			HistorySize:           -1,
+			MaxReasonLength:       100,
+		}, nil)
+		t.Cleanup(func() { h.Close() })
+
+		c := h.EffectiveConfig()
+		assert.Equal(t, time.Duration(-1), c.MinProbeInterval)
+		assert.Equal(t, ptr.Of(false), c.ReportsOverrideProbes)
+		assert.Equal(t, -1, c.HistorySize)
+		assert.Equal(t, 100, c.MaxReasonLength)
+		assert.Zero(t, c.ConfirmationInterval)
+		assert.Zero(t, c.StartupBurstInterval)
+	})
+
+	t.Run("current probe interval", func(t *testing.T) {
+		h := New(config.AppHealthConfig{
+			ProbeInterval:    10 * time.Millisecond,
+			MinProbeInterval: 50 * time.Millisecond,
+		}, nil)
+		clock := clocktesting.NewFakeClock(time.Now())
+		h.clock = clock
+		t.Cleanup(func() { h.Close() })
+
+		// Clamped to the minimum
+		assert.Equal(t, 50*time.Millisecond, h.EffectiveConfig().ProbeInterval)
+
+		require.NoError(t, h.OverrideInterval(time.Second, time.Minute))
+		assert.Equal(t, time.Second, h.EffectiveConfig().ProbeInterval)
+
+		clock.Step(time.Minute)
+		assert.Equal(t, 50*time.Millisecond, h.EffectiveConfig().ProbeInterval)
+	})
+
+	t.Run("returns a copy", func(t *testing.T) {
+		h := New(config.AppHealthConfig{
+			ProbeInterval: time.Second,
+			MaintenanceSchedule: []config.AppHealthMaintenanceWindow{
+				{Start: time.Hour, Duration: time.Hour},
+			},
+		}, nil)
+		t.Cleanup(func() { h.Close() })
+
+		c := h.EffectiveConfig()
+		c.MaintenanceSchedule[0].Start = 0
+		assert.Equal(t, time.Hour, h.config.MaintenanceSchedule[0].Start)
+	})
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"time"
+)
+
+// confirmTransition returns true if the probe result in status can be applied.
+// If ConfirmationProbes is set and the result would change the app's status, it runs the confirmation probes and returns true only if the majority of them agree with status.
+// The probes run within one ProbeInterval, so confirmation delays a genuine transition by at most one interval plus the probe timeouts.
+func (h *AppHealth) confirmTransition(ctx context.Context, status *Status) bool {
+	n := h.config.ConfirmationProbes
+	if n <= 0 || !h.wouldTransition(status) {
+		return true
+	}
+
+	interval := h.confirmationInterval()
+	log.Debugf("App health probe result would change the app's status; running %d confirmation probes", n)
+	var agree int32
+	timer := h.clock.NewTimer(interval)
+	defer timer.Stop()
+	for i := range n {
+		if i > 0 {
+			timer.Reset(interval)
+		}
+		select {
+		case <-timer.C():
+		case <-ctx.Done():
+			return false
+		case <-h.closeCh:
+			return false
+		}
+
+		if h.confirmationProbe(ctx) == status.IsHealthy {
+			agree++
+		}
+	}
+
+	if agree*2 <= n {
+		log.Infof("App health status change not confirmed: %d of %d confirmation probes agreed", agree, n)
+		return false
+	}
+	log.Debugf("App health status change confirmed: %d of %d confirmation probes agreed", agree, n)
+	return true
+}
+
+// confirmationInterval returns the interval between confirmation probes: ConfirmationInterval, capped so all confirmation probes fit in ProbeInterval.
+func (h *AppHealth) confirmationInterval() time.Duration {
+	interval := h.config.ProbeInterval / time.Duration(h.config.ConfirmationProbes)
+	if h.config.ConfirmationInterval > 0 && h.config.ConfirmationInterval < interval {
+		interval = h.config.ConfirmationInterval
+	}
+	return interval
+}
+
+// confirmationProbe runs a single confirmation probe, returning true if the app is healthy.
+func (h *AppHealth) confirmationProbe(parentCtx context.Context) bool {
+	ctx, cancel := context.WithTimeout(parentCtx, h.config.ProbeTimeout)
+	defer cancel()
+
+	status, err := h.probeFn(ctx)
+	return err == nil && status != nil && status.IsHealthy
+}
+
+// wouldTransition returns true if applying status would make the app cross the threshold.
+func (h *AppHealth) wouldTransition(status *Status) bool {
+	fc := h.failureCount.Load()
+	if status.IsHealthy {
+		return fc >= h.config.Threshold
+	}
+	return fc+1 == h.config.Threshold
+}
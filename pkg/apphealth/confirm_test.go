@@ -0,0 +1,130 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	"github.com/dapr/dapr/pkg/config"
+)
+
+func TestAppHealth_ConfirmationProbes(t *testing.T) {
+	// Each test case lists the results of the probes: the first one triggers the confirmation, the others confirm it
+	run := func(t *testing.T, results ...bool) *AppHealth {
+		t.Helper()
+
+		clock := clocktesting.NewFakeClock(time.Now())
+		var lock sync.Mutex
+		h := New(config.AppHealthConfig{
+			ProbeInterval:        time.Second,
+			ProbeTimeout:         100 * time.Millisecond,
+			Threshold:            1,
+			ConfirmationProbes:   3,
+			ConfirmationInterval: 100 * time.Millisecond,
+		}, func(context.Context) (*Status, error) {
+			lock.Lock()
+			defer lock.Unlock()
+			healthy := results[0]
+			results = results[1:]
+			return NewStatus(healthy, nil), nil
+		})
+		h.clock = clock
+		t.Cleanup(func() { h.Close() })
+		// Start healthy
+		h.setResult(t.Context(), NewStatus(true, nil))
+		require.True(t, h.IsHealthy())
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			h.doProbe(t.Context())
+		}()
+		for {
+			select {
+			case <-done:
+				lock.Lock()
+				defer lock.Unlock()
+				assert.Empty(t, results, "all probes must run")
+				return h
+			case <-time.After(time.Millisecond):
+				if clock.HasWaiters() {
+					clock.Step(100 * time.Millisecond)
+				}
+			}
+		}
+	}
+
+	t.Run("confirmed by majority", func(t *testing.T) {
+		h := run(t, false, false, true, false)
+		assert.False(t, h.IsHealthy())
+		require.Len(t, h.History(), 2)
+	})
+
+	t.Run("not confirmed", func(t *testing.T) {
+		h := run(t, false, true, false, true)
+		assert.True(t, h.IsHealthy())
+		assert.Len(t, h.History(), 1)
+	})
+
+	t.Run("recovery is confirmed too", func(t *testing.T) {
+		clock := clocktesting.NewFakeClock(time.Now())
+		h := New(config.AppHealthConfig{
+			ProbeInterval:      time.Second,
+			ProbeTimeout:       100 * time.Millisecond,
+			Threshold:          1,
+			ConfirmationProbes: 2,
+		}, func(context.Context) (*Status, error) {
+			return NewStatus(true, nil), nil
+		})
+		h.clock = clock
+		t.Cleanup(func() { h.Close() })
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			h.doProbe(t.Context())
+		}()
+		// Without ConfirmationInterval, the probes are spread over the probe interval
+		for range 2 {
+			assert.Eventually(t, clock.HasWaiters, time.Second, time.Microsecond)
+			clock.Step(500 * time.Millisecond)
+		}
+		<-done
+		assert.True(t, h.IsHealthy())
+	})
+
+	t.Run("no confirmation without a transition", func(t *testing.T) {
+		h := New(config.AppHealthConfig{
+			ProbeInterval:      time.Second,
+			ProbeTimeout:       100 * time.Millisecond,
+			Threshold:          3,
+			ConfirmationProbes: 3,
+		}, func(context.Context) (*Status, error) {
+			return NewStatus(false, nil), nil
+		})
+		t.Cleanup(func() { h.Close() })
+		h.setResult(t.Context(), NewStatus(true, nil))
+
+		// The first failure doesn't cross the threshold, so it's applied right away
+		h.doProbe(t.Context())
+		assert.Equal(t, int32(1), h.failureCount.Load())
+	})
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+)
+
+// DependencyRegistry holds the health of the app's dependencies, as reported by their sidecars.
+// Its Probe method is a ProbeFunction that is healthy when all required dependencies are healthy, so it can be added to a ProbeSet to make the app's health depend on them.
+type DependencyRegistry struct {
+	lock   sync.RWMutex
+	deps   map[string]*dependency
+	maxAge time.Duration
+	clock  clock.PassiveClock
+}
+
+type dependency struct {
+	required  bool
+	status    *Status
+	updatedAt time.Time
+}
+
+// DependencyInfo describes a dependency registered in a DependencyRegistry.
+type DependencyInfo struct {
+	ID       string
+	Required bool
+	// LastStatus is nil if no status has been set for the dependency yet.
+	LastStatus *Status
+	UpdatedAt  time.Time
+	// Stale is true if the last status is older than the registry's maximum age.
+	Stale bool
+}
+
+// NewDependencyRegistry returns a new, empty DependencyRegistry.
+// Statuses older than maxAge are stale, and stale dependencies are considered unhealthy; if maxAge is zero, statuses never become stale.
+func NewDependencyRegistry(maxAge time.Duration) *DependencyRegistry {
+	return &DependencyRegistry{
+		deps:   make(map[string]*dependency),
+		maxAge: maxAge,
+		clock:  &clock.RealClock{},
+	}
+}
+
+// Register adds a dependency with no status yet.
+// Required dependencies make the registry unhealthy when they're unhealthy, stale, or have no status; the others are only reported in the status' Details.
+func (r *DependencyRegistry) Register(id string, required bool) error {
+	if id == "" {
+		return errors.New("dependency ID is empty")
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, ok := r.deps[id]; ok {
+		return fmt.Errorf("dependency %q is already registered", id)
+	}
+	r.deps[id] = &dependency{required: required}
+	return nil
+}
+
+// Remove unregisters a dependency, returning false if it wasn't registered.
+func (r *DependencyRegistry) Remove(id string) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	_, ok := r.deps[id]
+	delete(r.deps, id)
+	return ok
+}
+
+// SetDependencyStatus records the status of a dependency, registering it as required if it wasn't registered.
+// A nil status clears the dependency's status.
+func (r *DependencyRegistry) SetDependencyStatus(id string, status *Status) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	d, ok := r.deps[id]
+	if !ok {
+		d = &dependency{required: true}
+		r.deps[id] = d
+	}
+	d.status = status
+	d.updatedAt = r.clock.Now()
+}
+
+// Probe returns the combined status of the dependencies.
+// It's healthy when all required dependencies have a status that is healthy and not stale; the result of each dependency is included in the status' Details.
+// A registry with no dependencies is healthy.
+func (r *DependencyRegistry) Probe(context.Context) (*Status, error) {
+	return r.Status(), nil
+}
+
+// Status returns the combined status of the dependencies, like Probe.
+func (r *DependencyRegistry) Status() *Status {
+	now := r.clock.Now()
+
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	var failed []string
+	details := make(map[string]CheckResult, len(r.deps))
+	for id, d := range r.deps {
+		res := r.checkResult(d, now)
+		details[id] = res
+		if d.required && !res.IsHealthy {
+			failed = append(failed, id)
+		}
+	}
+
+	var status *Status
+	if len(failed) > 0 {
+		slices.Sort(failed)
+		reason := "Failed dependencies: " + strings.Join(failed, ", ")
+		status = NewStatus(false, &reason)
+	} else {
+		status = NewStatus(true, nil)
+	}
+	if len(details) > 0 {
+		status.Details = details
+	}
+	return status
+}
+
+// checkResult returns the result of a dependency, which is unhealthy if it has no status or the status is stale.
+func (r *DependencyRegistry) checkResult(d *dependency, now time.Time) CheckResult {
+	switch {
+	case d.status == nil:
+		reason := "No status received"
+		return CheckResult{Reason: &reason}
+	case r.isStale(d, now):
+		reason := fmt.Sprintf("Status is stale: last received %v ago", now.Sub(d.updatedAt).Round(time.Millisecond))
+		return CheckResult{Reason: &reason}
+	default:
+		return CheckResult{IsHealthy: d.status.IsHealthy, Reason: d.status.Reason}
+	}
+}
+
+func (r *DependencyRegistry) isStale(d *dependency, now time.Time) bool {
+	return r.maxAge > 0 && d.status != nil && now.Sub(d.updatedAt) > r.maxAge
+}
+
+// Dependencies returns information about the registered dependencies, sorted by ID.
+func (r *DependencyRegistry) Dependencies() []DependencyInfo {
+	now := r.clock.Now()
+
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	res := make([]DependencyInfo, 0, len(r.deps))
+	for id, d := range r.deps {
+		res = append(res, DependencyInfo{
+			ID:         id,
+			Required:   d.required,
+			LastStatus: d.status,
+			UpdatedAt:  d.updatedAt,
+			Stale:      r.isStale(d, now),
+		})
+	}
+	slices.SortFunc(res, func(a, b DependencyInfo) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	return res
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	"github.com/dapr/dapr/pkg/config"
+)
+
+func TestDependencyRegistry(t *testing.T) {
+	newRegistry := func(maxAge time.Duration) (*DependencyRegistry, *clocktesting.FakeClock) {
+		r := NewDependencyRegistry(maxAge)
+		clock := clocktesting.NewFakeClock(time.Now())
+		r.clock = clock
+		return r, clock
+	}
+	reason := func(s string) *string { return &s }
+
+	t.Run("empty registry is healthy", func(t *testing.T) {
+		r, _ := newRegistry(0)
+		status, err := r.Probe(t.Context())
+		require.NoError(t, err)
+		assert.True(t, status.IsHealthy)
+		assert.Nil(t, status.Details)
+	})
+
+	t.Run("required dependency without status is unhealthy", func(t *testing.T) {
+		r, _ := newRegistry(0)
+		require.NoError(t, r.Register("db", true))
+		status := r.Status()
+		assert.False(t, status.IsHealthy)
+		require.NotNil(t, status.Reason)
+		assert.Equal(t, "Failed dependencies: db", *status.Reason)
+		require.Contains(t, status.Details, "db")
+		assert.Equal(t, "No status received", *status.Details["db"].Reason)
+	})
+
+	t.Run("unhealthy required dependency makes the registry unhealthy", func(t *testing.T) {
+		r, _ := newRegistry(0)
+		r.SetDependencyStatus("orders", NewStatus(true, nil))
+		r.SetDependencyStatus("cache", NewStatus(false, reason("evicting")))
+		r.SetDependencyStatus("db", NewStatus(false, nil))
+		status := r.Status()
+		assert.False(t, status.IsHealthy)
+		assert.Equal(t, "Failed dependencies: cache, db", *status.Reason)
+		assert.True(t, status.Details["orders"].IsHealthy)
+		assert.Equal(t, "evicting", *status.Details["cache"].Reason)
+
+		r.SetDependencyStatus("cache", NewStatus(true, nil))
+		r.SetDependencyStatus("db", NewStatus(true, nil))
+		assert.True(t, r.Status().IsHealthy)
+	})
+
+	t.Run("optional dependencies are only reported", func(t *testing.T) {
+		r, _ := newRegistry(0)
+		require.NoError(t, r.Register("metrics", false))
+		r.SetDependencyStatus("metrics", NewStatus(false, reason("down")))
+		status := r.Status()
+		assert.True(t, status.IsHealthy)
+		assert.False(t, status.Details["metrics"].IsHealthy)
+	})
+
+	t.Run("stale statuses are unhealthy", func(t *testing.T) {
+		r, clock := newRegistry(10 * time.Second)
+		r.SetDependencyStatus("db", NewStatus(true, nil))
+		clock.Step(10 * time.Second)
+		assert.True(t, r.Status().IsHealthy)
+
+		clock.Step(time.Second)
+		status := r.Status()
+		assert.False(t, status.IsHealthy)
+		assert.Equal(t, "Status is stale: last received 11s ago", *status.Details["db"].Reason)
+		deps := r.Dependencies()
+		require.Len(t, deps, 1)
+		assert.True(t, deps[0].Stale)
+
+		// A new status refreshes the dependency
+		r.SetDependencyStatus("db", NewStatus(true, nil))
+		assert.True(t, r.Status().IsHealthy)
+	})
+
+	t.Run("register and remove", func(t *testing.T) {
+		r, clock := newRegistry(0)
+		require.Error(t, r.Register("", true))
+		require.NoError(t, r.Register("db", false))
+		require.Error(t, r.Register("db", true))
+		r.SetDependencyStatus("cache", NewStatus(true, nil))
+
+		deps := r.Dependencies()
+		require.Len(t, deps, 2)
+		assert.Equal(t, "cache", deps[0].ID)
+		assert.True(t, deps[0].Required)
+		assert.Equal(t, clock.Now(), deps[0].UpdatedAt)
+		assert.Equal(t, "db", deps[1].ID)
+		assert.False(t, deps[1].Required)
+		assert.Nil(t, deps[1].LastStatus)
+
+		assert.True(t, r.Remove("db"))
+		assert.False(t, r.Remove("db"))
+		assert.Len(t, r.Dependencies(), 1)
+	})
+}
+
+func TestDependencyRegistryInProbeSet(t *testing.T) {
+	deps := NewDependencyRegistry(0)
+	set := NewProbeSet()
+	require.NoError(t, set.Add("app", func(context.Context) (*Status, error) {
+		return NewStatus(true, nil), nil
+	}))
+	require.NoError(t, set.Add("dependencies", deps.Probe))
+
+	h := New(config.AppHealthConfig{
+		ProbeTimeout: time.Second,
+		Threshold:    1,
+	}, set.Probe)
+	t.Cleanup(func() { h.Close() })
+
+	deps.SetDependencyStatus("db", NewStatus(true, nil))
+	_, err := h.ManualTrigger(t.Context())
+	require.NoError(t, err)
+	assert.True(t, h.IsHealthy())
+
+	// A required dependency going down makes the app unhealthy
+	deps.SetDependencyStatus("db", NewStatus(false, nil))
+	_, err = h.ManualTrigger(t.Context())
+	require.NoError(t, err)
+	assert.False(t, h.IsHealthy())
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/dapr/dapr/pkg/config"
+	env "github.com/dapr/dapr/pkg/config/env"
+)
+
+// ConfigFromEnv returns the configuration for app health probes read from the environment, for launchers that can't pass an AppHealthConfig.
+// It reads the interval and timeout as duration strings (e.g. "5s"), the threshold as an integer, and probe-only as a boolean; unset variables use the defaults.
+// The configuration is validated with the same rules enforced by StartProbes.
+func ConfigFromEnv() (config.AppHealthConfig, error) {
+	c := config.AppHealthConfig{
+		ProbeInterval: config.AppHealthConfigDefaultProbeInterval,
+		ProbeTimeout:  config.AppHealthConfigDefaultProbeTimeout,
+		Threshold:     config.AppHealthConfigDefaultThreshold,
+	}
+
+	var err error
+	if c.ProbeInterval, err = durationFromEnv(env.AppHealthProbeInterval, c.ProbeInterval); err != nil {
+		return config.AppHealthConfig{}, err
+	}
+	if c.ProbeTimeout, err = durationFromEnv(env.AppHealthProbeTimeout, c.ProbeTimeout); err != nil {
+		return config.AppHealthConfig{}, err
+	}
+	if v, ok := os.LookupEnv(env.AppHealthThreshold); ok {
+		threshold, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			return config.AppHealthConfig{}, fmt.Errorf("invalid value for %s: %q is not an integer", env.AppHealthThreshold, v)
+		}
+		c.Threshold = int32(threshold)
+	}
+	if v, ok := os.LookupEnv(env.AppHealthProbeOnly); ok {
+		if c.ProbeOnly, err = strconv.ParseBool(v); err != nil {
+			return config.AppHealthConfig{}, fmt.Errorf("invalid value for %s: %q is not a boolean", env.AppHealthProbeOnly, v)
+		}
+	}
+
+	if err = validateConfig(c); err != nil {
+		return config.AppHealthConfig{}, fmt.Errorf("invalid app health configuration from environment: %w", err)
+	}
+	return c, nil
+}
+
+func durationFromEnv(name string, def time.Duration) (time.Duration, error) {
+	v, ok := os.LookupEnv(name)
+	if !ok {
+		return def, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value for %s: %q is not a duration", name, v)
+	}
+	return d, nil
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/dapr/pkg/config"
+	env "github.com/dapr/dapr/pkg/config/env"
+)
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		c, err := ConfigFromEnv()
+		require.NoError(t, err)
+		assert.Equal(t, config.AppHealthConfig{
+			ProbeInterval: config.AppHealthConfigDefaultProbeInterval,
+			ProbeTimeout:  config.AppHealthConfigDefaultProbeTimeout,
+			Threshold:     config.AppHealthConfigDefaultThreshold,
+		}, c)
+	})
+
+	t.Run("all values", func(t *testing.T) {
+		t.Setenv(env.AppHealthProbeInterval, "10s")
+		t.Setenv(env.AppHealthProbeTimeout, "250ms")
+		t.Setenv(env.AppHealthThreshold, "5")
+		t.Setenv(env.AppHealthProbeOnly, "true")
+		c, err := ConfigFromEnv()
+		require.NoError(t, err)
+		assert.Equal(t, config.AppHealthConfig{
+			ProbeInterval: 10 * time.Second,
+			ProbeTimeout:  250 * time.Millisecond,
+			Threshold:     5,
+			ProbeOnly:     true,
+		}, c)
+	})
+
+	errCases := map[string]struct {
+		env     map[string]string
+		wantErr string
+	}{
+		"malformed interval": {
+			env:     map[string]string{env.AppHealthProbeInterval: "5"},
+			wantErr: `invalid value for APP_HEALTH_PROBE_INTERVAL: "5" is not a duration`,
+		},
+		"malformed timeout": {
+			env:     map[string]string{env.AppHealthProbeTimeout: "fast"},
+			wantErr: `invalid value for APP_HEALTH_PROBE_TIMEOUT: "fast" is not a duration`,
+		},
+		"malformed threshold": {
+			env:     map[string]string{env.AppHealthThreshold: "three"},
+			wantErr: `invalid value for APP_HEALTH_THRESHOLD: "three" is not an integer`,
+		},
+		"malformed probe-only": {
+			env:     map[string]string{env.AppHealthProbeOnly: "maybe"},
+			wantErr: `invalid value for APP_HEALTH_PROBE_ONLY: "maybe" is not a boolean`,
+		},
+		"timeout larger than interval": {
+			env:     map[string]string{env.AppHealthProbeInterval: "1s", env.AppHealthProbeTimeout: "2s"},
+			wantErr: "app health checks probe timeouts must be smaller than probe intervals",
+		},
+		"zero interval": {
+			env:     map[string]string{env.AppHealthProbeInterval: "0s"},
+			wantErr: "probe interval must be larger than 0",
+		},
+		"threshold above maximum": {
+			env:     map[string]string{env.AppHealthThreshold: "10001"},
+			wantErr: "app health checks threshold must be between 0 and 10000",
+		},
+	}
+	for name, tc := range errCases {
+		t.Run(name, func(t *testing.T) {
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+			_, err := ConfigFromEnv()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.wantErr)
+		})
+	}
+}
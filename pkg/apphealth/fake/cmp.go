@@ -0,0 +1,74 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/dapr/dapr/pkg/apphealth"
+)
+
+// statusView is the representation of an apphealth.Status that is compared by StatusTransformer.
+type statusView struct {
+	Nil        bool
+	IsHealthy  bool
+	Reason     string
+	HasReason  bool
+	Code       string
+	Hint       string
+	Class      string
+	Source     string
+	RetryAfter time.Duration
+	Details    map[string]checkView
+}
+
+type checkView struct {
+	IsHealthy bool
+	Reason    string
+	HasReason bool
+}
+
+// StatusTransformer is a go-cmp option that compares apphealth.Status values the same way as Status.Equal, and renders diffs with the reason dereferenced.
+func StatusTransformer() cmp.Option {
+	return cmp.Transformer("apphealth.Status", func(s *apphealth.Status) statusView {
+		if s == nil {
+			return statusView{Nil: true}
+		}
+		v := statusView{
+			IsHealthy:  s.IsHealthy,
+			HasReason:  s.Reason != nil,
+			Code:       string(s.Code),
+			Hint:       s.RemediationHint,
+			Class:      s.FailureClass.String(),
+			Source:     s.Source.String(),
+			RetryAfter: s.RetryAfter,
+		}
+		if s.Reason != nil {
+			v.Reason = *s.Reason
+		}
+		if len(s.Details) > 0 {
+			v.Details = make(map[string]checkView, len(s.Details))
+			for name, d := range s.Details {
+				cv := checkView{IsHealthy: d.IsHealthy, HasReason: d.Reason != nil}
+				if d.Reason != nil {
+					cv.Reason = *d.Reason
+				}
+				v.Details[name] = cv
+			}
+		}
+		return v
+	})
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dapr/dapr/pkg/apphealth"
+)
+
+func TestStatusTransformer(t *testing.T) {
+	a, b := "down", "gone"
+	x := apphealth.NewStatus(false, &a)
+	y := apphealth.NewStatus(false, &a)
+	y.TimeUnix = x.TimeUnix + 10
+
+	assert.True(t, cmp.Equal(x, y, StatusTransformer()))
+	assert.True(t, cmp.Equal((*apphealth.Status)(nil), (*apphealth.Status)(nil), StatusTransformer()))
+	assert.False(t, cmp.Equal(x, nil, StatusTransformer()))
+
+	y.Reason = &b
+	diff := cmp.Diff(x, y, StatusTransformer())
+	assert.Contains(t, diff, `"down"`)
+	assert.Contains(t, diff, `"gone"`)
+	assert.Equal(t, x.Equal(y), cmp.Equal(x, y, StatusTransformer()))
+
+	y.Reason = &a
+	y.FailureClass = apphealth.FailureClassPermanent
+	assert.False(t, cmp.Equal(x, y, StatusTransformer()))
+	assert.Equal(t, x.Equal(y), cmp.Equal(x, y, StatusTransformer()))
+}
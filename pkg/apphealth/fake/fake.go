@@ -0,0 +1,95 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"github.com/dapr/dapr/pkg/apphealth"
+)
+
+// FakeChecker is an apphealth.Checker with a status that is set by the test.
+// Calling SetStatus invokes the change callback when the health changes.
+type FakeChecker struct {
+	lock     sync.Mutex
+	status   *apphealth.Status
+	cb       apphealth.ChangeCallback
+	reports  []*apphealth.Status
+	enqueued int
+}
+
+// New returns a FakeChecker that reports the app as healthy.
+func New() *FakeChecker {
+	return &FakeChecker{
+		status: apphealth.NewStatus(true, nil),
+	}
+}
+
+// SetStatus sets the status returned by GetStatus.
+func (f *FakeChecker) SetStatus(status *apphealth.Status) {
+	f.lock.Lock()
+	changed := f.status.IsHealthy != status.IsHealthy
+	f.status = status
+	cb := f.cb
+	f.lock.Unlock()
+
+	if changed && cb != nil {
+		cb(context.Background(), status)
+	}
+}
+
+func (f *FakeChecker) GetStatus() *apphealth.Status {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.status
+}
+
+func (f *FakeChecker) IsHealthy() bool {
+	return f.GetStatus().IsHealthy
+}
+
+func (f *FakeChecker) OnHealthChange(cb apphealth.ChangeCallback) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.cb = cb
+}
+
+// ReportHealth records the report, which can be retrieved with Reports; it doesn't change the status.
+func (f *FakeChecker) ReportHealth(status *apphealth.Status) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.reports = append(f.reports, status)
+	return nil
+}
+
+// Reports returns the statuses passed to ReportHealth.
+func (f *FakeChecker) Reports() []*apphealth.Status {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return append([]*apphealth.Status(nil), f.reports...)
+}
+
+func (f *FakeChecker) Enqueue() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.enqueued++
+}
+
+// Enqueued returns the number of times Enqueue was called.
+func (f *FakeChecker) Enqueued() int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.enqueued
+}
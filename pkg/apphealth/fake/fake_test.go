@@ -0,0 +1,46 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dapr/dapr/pkg/apphealth"
+)
+
+func Test_Fake(t *testing.T) {
+	var _ apphealth.Checker = New()
+	var _ apphealth.Checker = (*apphealth.AppHealth)(nil)
+
+	f := New()
+	assert.True(t, f.IsHealthy())
+
+	var calls []bool
+	f.OnHealthChange(func(_ context.Context, status *apphealth.Status) {
+		calls = append(calls, status.IsHealthy)
+	})
+	f.SetStatus(apphealth.NewStatus(false, nil))
+	f.SetStatus(apphealth.NewStatus(false, nil))
+	assert.False(t, f.IsHealthy())
+	assert.Equal(t, []bool{false}, calls)
+
+	f.ReportHealth(apphealth.NewStatus(true, nil))
+	f.Enqueue()
+	assert.Len(t, f.Reports(), 1)
+	assert.Equal(t, 1, f.Enqueued())
+	assert.False(t, f.IsHealthy())
+}
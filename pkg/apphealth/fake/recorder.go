@@ -0,0 +1,96 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dapr/dapr/pkg/apphealth"
+)
+
+// sequenceTimeout is how long AssertSequence waits for the expected number of transitions.
+const sequenceTimeout = 5 * time.Second
+
+// TransitionRecorder records the transitions of an apphealth.AppHealth, for asserting the order in which they happen.
+type TransitionRecorder struct {
+	lock   sync.Mutex
+	events []apphealth.TransitionEvent
+}
+
+// NewTransitionRecorder returns a TransitionRecorder subscribed to h's transitions.
+// It replaces the transition callback set with OnTransition, if any.
+func NewTransitionRecorder(h *apphealth.AppHealth) *TransitionRecorder {
+	r := &TransitionRecorder{}
+	h.OnTransition(r.Record)
+	return r
+}
+
+// Record records a transition; it's an apphealth.TransitionCallback.
+func (r *TransitionRecorder) Record(event apphealth.TransitionEvent) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.events = append(r.events, event)
+}
+
+// Events returns the recorded transitions in the order they happened.
+// Transition callbacks run concurrently, so they're sorted by ID rather than by the order they were recorded.
+func (r *TransitionRecorder) Events() []apphealth.TransitionEvent {
+	r.lock.Lock()
+	events := slices.Clone(r.events)
+	r.lock.Unlock()
+
+	slices.SortFunc(events, func(a, b apphealth.TransitionEvent) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return events
+}
+
+// Healthy returns the health after each recorded transition, in the order they happened.
+func (r *TransitionRecorder) Healthy() []bool {
+	events := r.Events()
+	res := make([]bool, len(events))
+	for i, e := range events {
+		res[i] = e.To.IsHealthy
+	}
+	return res
+}
+
+// AssertSequence asserts that the recorded transitions made the app healthy or unhealthy in the order given by want.
+// Since transitions are delivered asynchronously, it waits for up to 5 seconds for len(want) transitions to be recorded.
+func (r *TransitionRecorder) AssertSequence(t assert.TestingT, want ...bool) bool {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	deadline := time.Now().Add(sequenceTimeout)
+	for {
+		r.lock.Lock()
+		n := len(r.events)
+		r.lock.Unlock()
+		if n >= len(want) || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if want == nil {
+		want = []bool{}
+	}
+	return assert.Equal(t, want, r.Healthy(), "unexpected sequence of transitions (true is healthy)")
+}
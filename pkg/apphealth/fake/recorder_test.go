@@ -0,0 +1,70 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/dapr/pkg/apphealth"
+	"github.com/dapr/dapr/pkg/config"
+)
+
+func TestTransitionRecorder(t *testing.T) {
+	h := apphealth.New(config.AppHealthConfig{
+		Threshold: 1,
+	}, nil)
+	t.Cleanup(func() { h.Close() })
+	r := NewTransitionRecorder(h)
+
+	r.AssertSequence(t)
+
+	h.ReportHealth(apphealth.NewStatus(true, nil))
+	h.ReportHealth(apphealth.NewStatus(false, nil))
+	h.ReportHealth(apphealth.NewStatus(true, nil))
+	r.AssertSequence(t, true, false, true)
+
+	events := r.Events()
+	require.Len(t, events, 3)
+	for i, e := range events {
+		assert.Equal(t, uint64(i+1), e.ID)
+	}
+
+	t.Run("mismatch fails", func(t *testing.T) {
+		mock := &assert.CollectT{}
+		assert.False(t, r.AssertSequence(mock, true, true, true))
+	})
+}
+
+func ExampleTransitionRecorder() {
+	h := apphealth.New(config.AppHealthConfig{
+		Threshold: 1,
+	}, func(context.Context) (*apphealth.Status, error) {
+		return apphealth.NewStatus(true, nil), nil
+	})
+	r := NewTransitionRecorder(h)
+
+	h.ReportHealth(apphealth.NewStatus(true, nil))
+	h.ReportHealth(apphealth.NewStatus(false, nil))
+
+	// In tests, use r.AssertSequence(t, true, false)
+	// Close waits for the transitions to be delivered
+	h.Close()
+	fmt.Println(r.Healthy())
+	// Output: [true false]
+}
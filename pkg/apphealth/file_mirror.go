@@ -0,0 +1,82 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// FileMirrorCallback returns a ChangeCallback that writes "healthy" or "unhealthy" to the file at path on each transition, so external scripts can poll it.
+// The file is replaced atomically by writing to a temporary file in the same directory and renaming it, so readers never see a partial write.
+// Callbacks can run out of order, so a transition older than the last one written, per TransitionIDFromContext, is skipped.
+// Failures are logged and otherwise ignored.
+func FileMirrorCallback(path string) ChangeCallback {
+	// Serializes writes, since callbacks can be invoked concurrently; lastID is the ID of the last transition written
+	var (
+		lock   sync.Mutex
+		lastID uint64
+	)
+
+	return func(ctx context.Context, status *Status) {
+		content := "unhealthy\n"
+		if status.IsHealthy {
+			content = "healthy\n"
+		}
+
+		lock.Lock()
+		defer lock.Unlock()
+		if id, ok := TransitionIDFromContext(ctx); ok {
+			if id < lastID {
+				log.Debugf("Not mirroring app health transition %d to %s because transition %d was already written", id, path, lastID)
+				return
+			}
+			lastID = id
+		}
+		if err := writeFileAtomic(path, []byte(content)); err != nil {
+			log.Warnf("Failed to mirror app health status to %s: %v", path, err)
+		}
+	}
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	tmp := f.Name()
+	defer os.Remove(tmp)
+
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	//nolint:gosec
+	if err = os.Chmod(tmp, 0o644); err != nil {
+		return fmt.Errorf("failed to set permissions on temporary file: %w", err)
+	}
+	if err = os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to rename temporary file: %w", err)
+	}
+	return nil
+}
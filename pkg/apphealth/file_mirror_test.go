@@ -0,0 +1,69 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileMirrorCallback(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "health")
+	cb := FileMirrorCallback(path)
+
+	cb(t.Context(), NewStatus(true, nil))
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "healthy\n", string(b))
+
+	reason := "down"
+	cb(t.Context(), NewStatus(false, &reason))
+	b, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "unhealthy\n", string(b))
+
+	// No temporary files are left behind
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	t.Run("older transitions are skipped", func(t *testing.T) {
+		path := filepath.Join(dir, "ordered")
+		cb := FileMirrorCallback(path)
+
+		cb(withTransitionID(t.Context(), 2), NewStatus(false, nil))
+		// Delivered after a newer transition
+		cb(withTransitionID(t.Context(), 1), NewStatus(true, nil))
+		b, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "unhealthy\n", string(b))
+
+		cb(withTransitionID(t.Context(), 3), NewStatus(true, nil))
+		b, err = os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "healthy\n", string(b))
+	})
+
+	t.Run("missing directory is logged", func(t *testing.T) {
+		cb := FileMirrorCallback(filepath.Join(dir, "missing", "health"))
+		cb(t.Context(), NewStatus(true, nil))
+		_, err := os.Stat(filepath.Join(dir, "missing"))
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
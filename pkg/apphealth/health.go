@@ -17,13 +17,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
+	"math"
+	"math/rand/v2"
 	"strconv"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"k8s.io/utils/clock"
 
 	"github.com/dapr/dapr/pkg/config"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
 	"github.com/dapr/kit/logger"
 )
 
@@ -31,20 +36,125 @@ var log = logger.NewLogger("dapr.apphealth")
 
 // AppHealth manages the health checks for the app.
 type AppHealth struct {
-	config       config.AppHealthConfig
-	probeFn      ProbeFunction
-	changeCb     ChangeCallback
-	report       chan *Status
-	failureCount atomic.Int32
-	queue        chan struct{}
+	config          config.AppHealthConfig
+	probeFn         ProbeFunction
+	fallbackProbeFn atomic.Pointer[ProbeFunction]
+	changeCb        atomic.Pointer[ChangeCallback]
+	transitionCb    atomic.Pointer[TransitionCallback]
+	shouldProbe     atomic.Pointer[func() bool]
+	cbLimiter       *callbackLimiter
+	cbPool          *callbackPool
+	auditSink       atomic.Pointer[AuditSink]
+	slogger         atomic.Pointer[slog.Logger]
+	ndjson          atomic.Pointer[ndjsonOutput]
+	history         *transitionHistory
+	reasonFormatter atomic.Pointer[ReasonFormatter]
+	interceptor     atomic.Pointer[StatusInterceptor]
+	reportVerifier  atomic.Pointer[ReportVerifier]
+	breaker         atomic.Pointer[BreakerBridge]
+	report          chan *Status
+	failureCount    atomic.Int32
+	queue           chan struct{}
 
 	// lastReport is the last report as UNIX microseconds time.
+	// This is the wall-clock time, for display only: use lastReportAt to measure durations.
 	lastReport atomic.Int64
+	// lastReportAt is the time of the last report as returned by the clock, which includes the monotonic reading when available.
+	lastReportAt atomic.Pointer[time.Time]
+	// lastSource is the StatusSource of the last result.
+	lastSource atomic.Uint32
+	// lastReported is the last status reported by the app.
+	lastReported atomic.Pointer[Status]
+	// lastReportedAt is the time of the last status reported by the app, as returned by the clock.
+	lastReportedAt atomic.Pointer[time.Time]
+	// lastProbeAt is the time the most recent probe completed.
+	lastProbeAt atomic.Pointer[time.Time]
+	// healthyCache is the status returned by GetStatus while the app is healthy.
+	healthyCache atomic.Pointer[Status]
+	// phase is the Phase of the probe loop.
+	phase atomic.Uint32
+	// probeNotBefore is the time before which probes are skipped because of a Retry-After hint.
+	probeNotBefore atomic.Pointer[time.Time]
+	// lenientUntil is the time until which failures don't count towards the threshold.
+	lenientUntil atomic.Pointer[time.Time]
+	// maintenance is true while a window of the MaintenanceSchedule is active, from when its start is noticed to when its end is.
+	maintenance atomic.Bool
+	// quietUntil is the end of the StartupQuietPeriod, set when the probe loop first starts.
+	quietUntil atomic.Pointer[time.Time]
+	// barrier is the ReadyBarrier, if one was requested.
+	barrier     atomic.Pointer[ReadyBarrier]
+	barrierOnce sync.Once
+	// heartbeatStop stops the healthy heartbeat goroutine, if running.
+	heartbeatStop chan struct{}
+	heartbeatLock sync.Mutex
+	// transitioned is set after the first transition.
+	transitioned atomic.Bool
+	// draining is set when Shutdown is called, after which results are ignored.
+	draining atomic.Bool
+	// lastSuccessReason is the reason of the last healthy result, if it had one.
+	lastSuccessReason atomic.Pointer[string]
+	// lastDetails are the check details returned by the most recent probe.
+	lastDetails atomic.Pointer[map[string]CheckResult]
+	// lastHint is the remediation hint of the most recent failure, cleared when a healthy result is recorded.
+	lastHint atomic.Pointer[string]
+	// determined is set once the first result has been applied.
+	determined atomic.Bool
+	// failureSamples are the failure counts after the most recent results.
+	failureSamples failureSamples
+	// latencies are the latencies of the most recent probes; nil if LatencySampleSize is zero.
+	latencies *latencySamples
+	// transitionSeq is the ID of the most recent transition.
+	transitionSeq atomic.Uint64
+	// intervalOverride is the temporary probe interval set with OverrideInterval.
+	intervalOverride atomic.Pointer[intervalOverride]
+	// intervalCh wakes up the probe loop when the interval is overridden.
+	intervalCh chan struct{}
+	// syncReports are the reports received with ReportHealthSync waiting to be applied by the probe loop, guarded by runningLock; syncReportCh wakes up the loop.
+	syncReports  []syncReport
+	syncReportCh chan struct{}
+	// recoveryTimer ends the RecoveryGrace window, if one is running; recoveryGen identifies the window.
+	recoveryTimer clock.Timer
+	recoveryGen   uint64
+	recoveryLock  sync.Mutex
+	// probeCancel cancels the in-flight probe, if ReportsCancelProbes is set.
+	probeCancel atomic.Pointer[context.CancelCauseFunc]
+	// running is the number of probe loops running; runningLock guards it so reports aren't applied concurrently with a loop.
+	running     int
+	runningLock sync.Mutex
+	// resultLock is held for reading while a result is applied, so Close can wait for results in flight.
+	resultLock sync.RWMutex
+	// onceHealthyCb is invoked the first time the app becomes healthy; onceHealthyLock guards it and becameHealthy.
+	onceHealthyCb    func(ctx context.Context)
+	onceHealthyLock  sync.Mutex
+	becameHealthy    bool
+	onceHealthyFired atomic.Bool
+	// hasBeenHealthy is set on the first healthy result and never cleared.
+	hasBeenHealthy atomic.Bool
+	// callbackFailures is the number of callbacks that panicked.
+	callbackFailures atomic.Int64
+	// persistFn is invoked with a snapshot after transitions, throttled to PersistInterval; persistLock guards the throttling state.
+	persistFn    atomic.Pointer[PersistFunc]
+	persistLock  sync.Mutex
+	lastPersist  time.Time
+	persistTimer clock.Timer
 
-	clock   clock.WithTicker
+	clock   clock.WithTickerAndDelayedExecution
+	rand    *rand.Rand
 	wg      sync.WaitGroup
 	closed  atomic.Bool
 	closeCh chan struct{}
+	done    chan struct{}
+
+	clampWarnOnce sync.Once
+}
+
+// Checker is the behavior of AppHealth used by consumers, allowing them to depend on an interface that can be faked in tests.
+type Checker interface {
+	GetStatus() *Status
+	IsHealthy() bool
+	OnHealthChange(cb ChangeCallback)
+	ReportHealth(status *Status) error
+	Enqueue()
 }
 
 // ProbeFunction is the signature of the function that performs health probes.
@@ -64,6 +174,22 @@ func New(config config.AppHealthConfig, probeFn ProbeFunction) *AppHealth {
 		queue:   make(chan struct{}, 1),
 		clock:   &clock.RealClock{},
 		closeCh: make(chan struct{}),
+		done:    make(chan struct{}),
+
+		intervalCh:   make(chan struct{}, 1),
+		syncReportCh: make(chan struct{}, 1),
+	}
+
+	if config.MaxCallbacksPerSecond > 0 {
+		a.cbLimiter = newCallbackLimiter(a, config.MaxCallbacksPerSecond)
+	}
+	a.history = newTransitionHistory(a.historySize())
+	if config.LatencySampleSize > 0 {
+		a.latencies = newLatencySamples(config.LatencySampleSize)
+	}
+
+	if config.MaxCallbackGoroutines > 0 {
+		a.cbPool = newCallbackPool(a, config.MaxCallbackGoroutines, config.DropCallbacksWhenSaturated)
 	}
 
 	// Initial state is unhealthy until we validate it
@@ -74,30 +200,122 @@ func New(config config.AppHealthConfig, probeFn ProbeFunction) *AppHealth {
 
 // OnHealthChange sets the callback that is invoked when the health of the app changes (app becomes either healthy or unhealthy).
 func (h *AppHealth) OnHealthChange(cb ChangeCallback) {
-	h.changeCb = cb
+	h.changeCb.Store(&cb)
 }
 
-// StartProbes starts polling the app on the interval.
-func (h *AppHealth) StartProbes(ctx context.Context) error {
-	if h.closed.Load() {
-		return errors.New("app health is closed")
+// TransitionCallback is the signature of the function that is invoked with both the previous and new status when the health of the app changes.
+type TransitionCallback func(event TransitionEvent)
+
+// OnTransition sets the callback that is invoked when the health of the app changes, with the status before and after the transition.
+// It's invoked in addition to the change callback set with OnHealthChange, for the same transitions, but isn't subject to MaxCallbacksPerSecond.
+func (h *AppHealth) OnTransition(cb TransitionCallback) {
+	h.transitionCb.Store(&cb)
+}
+
+// OnceHealthy sets a callback that is invoked exactly once, the first time the app becomes healthy, for one-time initialization.
+// It's invoked even if the initial transition isn't delivered to the change callback, and right away if the app already became healthy before it was set.
+func (h *AppHealth) OnceHealthy(cb func(ctx context.Context)) {
+	h.onceHealthyLock.Lock()
+	h.onceHealthyCb = cb
+	healthy := h.becameHealthy
+	h.onceHealthyLock.Unlock()
+
+	if healthy {
+		h.fireOnceHealthy(context.Background())
 	}
+}
 
-	if h.probeFn == nil {
-		return errors.New("cannot start probes with nil probe function")
+// fireOnceHealthy records that the app became healthy and invokes the OnceHealthy callback in background, unless it was already invoked.
+func (h *AppHealth) fireOnceHealthy(ctx context.Context) {
+	h.onceHealthyLock.Lock()
+	h.becameHealthy = true
+	cb := h.onceHealthyCb
+	h.onceHealthyLock.Unlock()
+
+	if cb == nil || !h.onceHealthyFired.CompareAndSwap(false, true) {
+		return
 	}
-	if h.config.ProbeInterval <= 0 {
-		return errors.New("probe interval must be larger than 0")
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+		defer h.recoverCallback()
+		cb(ctx)
+	}()
+}
+
+// SetShouldProbe sets a function that is consulted before each scheduled probe, for example to probe only on the leader when sidecars are leader-elected.
+// When it returns false the probe is skipped without counting a failure, so the status stays at its last value.
+// Passing nil probes on every cycle again.
+func (h *AppHealth) SetShouldProbe(fn func() bool) {
+	h.shouldProbe.Store(&fn)
+}
+
+// SetFallbackProbe sets a secondary probe function that is used when the primary probe function returns an error.
+// The fallback is not used when the primary probe completes and reports the app as unhealthy.
+func (h *AppHealth) SetFallbackProbe(fn ProbeFunction) {
+	h.fallbackProbeFn.Store(&fn)
+}
+
+// SwapHealthChange atomically sets the callback that is invoked when the health of the app changes, and returns the previously-set callback (which may be nil).
+// This allows wrapping the existing callback, for example to decorate it.
+func (h *AppHealth) SwapHealthChange(cb ChangeCallback) (old ChangeCallback) {
+	prev := h.changeCb.Swap(&cb)
+	if prev == nil {
+		return nil
 	}
-	if h.config.ProbeTimeout > h.config.ProbeInterval {
-		return errors.New("app health checks probe timeouts must be smaller than probe intervals")
+	return *prev
+}
+
+// StartProbes starts polling the app on the interval in a background goroutine.
+func (h *AppHealth) StartProbes(ctx context.Context) error {
+	if err := h.validateProbes(); err != nil {
+		return err
 	}
 
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+		if err := h.RunProbes(ctx); err != nil {
+			log.Errorf("App health probes stopped with error: %v", err)
+		}
+	}()
+
+	return nil
+}
+
+// RunProbes polls the app on the interval, blocking until the context is canceled or the object is closed.
+// This allows running the probe loop on a goroutine managed by the caller, for example as part of an errgroup.
+func (h *AppHealth) RunProbes(ctx context.Context) error {
+	if err := h.validateProbes(); err != nil {
+		return err
+	}
+
+	h.runningLock.Lock()
+	h.running++
+	h.runningLock.Unlock()
+	defer func() {
+		h.runningLock.Lock()
+		defer h.runningLock.Unlock()
+		h.running--
+		if h.running == 0 {
+			h.applyPendingReport()
+			h.applySyncReports(context.Background(), h.takeSyncReports())
+		}
+	}()
+
 	log.Info("App health probes starting")
+	if h.config.StartupQuietPeriod > 0 {
+		until := h.clock.Now().Add(h.config.StartupQuietPeriod)
+		h.quietUntil.CompareAndSwap(nil, &until)
+	}
+
+	h.wg.Add(1)
+	defer h.wg.Done()
 
 	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
-	h.wg.Add(2)
+	h.wg.Add(1)
 	go func() {
 		defer h.wg.Done()
 		defer cancel()
@@ -107,35 +325,268 @@ func (h *AppHealth) StartProbes(ctx context.Context) error {
 		}
 	}()
 
-	go func() {
-		defer h.wg.Done()
+	// An "alive" log is emitted periodically if configured, as a liveness signal for the loop itself
+	var aliveCh <-chan time.Time
+	if h.config.AliveLogInterval > 0 {
+		aliveTicker := h.clock.NewTicker(h.config.AliveLogInterval)
+		defer aliveTicker.Stop()
+		aliveCh = aliveTicker.C()
+	}
 
-		ticker := h.clock.NewTicker(h.config.ProbeInterval)
-		ch := ticker.C()
-		defer ticker.Stop()
+	// Until the app is healthy, the first probes are run in a burst, StartupBurstInterval apart
+	burst := h.config.StartupBurst
+	if h.hasBeenHealthy.Load() {
+		burst = 0
+	}
 
-		for {
-			select {
-			case <-ctx.Done():
-				ticker.Stop()
-				log.Info("App health probes stopping")
-				return
-			case status := <-h.report:
-				log.Debug("Received health status report")
-				h.setResult(ctx, status)
-			case <-ch:
-				log.Debug("Probing app health")
-				h.Enqueue()
-			case <-h.queue:
-				// Run synchronously so the loop is blocked
-				h.doProbe(ctx)
-			}
+	// The timer is re-armed after each probe so that probes start ProbeInterval apart, regardless of how long they take
+	var (
+		timer   clock.Timer
+		ch      <-chan time.Time
+		startCh <-chan time.Time
+	)
+	defer func() {
+		if timer != nil {
+			timer.Stop()
 		}
 	}()
 
+	// Delay the first probe by a random amount so sidecars started together don't all probe at once
+	if delay := h.startupDelay(); delay > 0 {
+		log.Debugf("Delaying first app health probe by %v", delay)
+		startTimer := h.clock.NewTimer(delay)
+		defer startTimer.Stop()
+		startCh = startTimer.C()
+	} else {
+		timer = h.clock.NewTimer(h.loopInterval(burst))
+		ch = timer.C()
+	}
+
+	defer h.setPhase(PhaseStopped)
+	for {
+		h.setPhase(PhaseWaitingForTick)
+		select {
+		case <-ctx.Done():
+			log.Info("App health probes stopping")
+			return nil
+		case <-startCh:
+			startCh = nil
+			timer = h.clock.NewTimer(h.loopInterval(burst))
+			ch = timer.C()
+		case <-aliveCh:
+			h.logAlive()
+		case <-h.intervalCh:
+			if timer != nil {
+				h.scheduleNextProbe(timer, h.probeInterval())
+			}
+		case status := <-h.report:
+			log.Debug("Received health status report")
+			h.setPhase(PhaseReportingResult)
+			h.setResult(ctx, status)
+		case <-h.syncReportCh:
+			h.runningLock.Lock()
+			reports := h.takeSyncReports()
+			h.runningLock.Unlock()
+			log.Debug("Received synchronous health status report")
+			h.setPhase(PhaseReportingResult)
+			h.applySyncReports(ctx, reports)
+		case <-ch:
+			if nb := h.probeNotBefore.Load(); nb != nil && h.clock.Now().Before(*nb) {
+				log.Debug("Skipping app health probe because of a Retry-After hint")
+				timer.Reset(h.probeInterval())
+				continue
+			}
+			if b := h.breakerBridge(); b != nil && !b.AllowProbe() {
+				log.Debug("Skipping app health probe because the circuit breaker is open")
+				timer.Reset(h.probeInterval())
+				continue
+			}
+			if fn := h.shouldProbe.Load(); fn != nil && *fn != nil && !(*fn)() {
+				log.Debug("Skipping app health probe because the probe gate is closed")
+				timer.Reset(h.probeInterval())
+				continue
+			}
+			log.Debug("Probing app health")
+			h.Enqueue()
+		case <-h.queue:
+			// Run synchronously so the loop is blocked
+			h.setPhase(PhaseProbing)
+			start := h.clock.Now()
+			h.doProbe(ctx)
+			if burst > 0 {
+				burst--
+				if h.hasBeenHealthy.Load() {
+					log.Debugf("App is healthy; ending the startup probe burst with %d probes left", burst)
+					burst = 0
+				}
+			}
+			if timer != nil {
+				h.scheduleNextProbe(timer, h.loopInterval(burst)-h.clock.Since(start))
+			}
+		}
+	}
+}
+
+// applyPendingReport applies the report that was queued for the probe loop but not received before it exited, if any.
+// It must be called with runningLock held; if the object is closed, setResult discards the report.
+func (h *AppHealth) applyPendingReport() {
+	select {
+	case status := <-h.report:
+		h.setResult(context.Background(), status)
+	default:
+	}
+}
+
+// logAlive logs that the probe loop is running, with the time since the last probe and the current status.
+func (h *AppHealth) logAlive() {
+	status := "unhealthy"
+	if h.IsHealthy() {
+		status = "healthy"
+	}
+	if lp := h.lastProbeAt.Load(); lp != nil {
+		log.Infof("App health probe loop alive, last probe %v ago, status %s", h.clock.Since(*lp).Round(time.Millisecond), status)
+	} else {
+		log.Infof("App health probe loop alive, no probe yet, status %s", status)
+	}
+}
+
+// scheduleNextProbe re-arms the probe timer to fire after wait, discarding any tick that wasn't consumed.
+// If the probe took longer than the interval, the next probe is queued right away.
+func (h *AppHealth) scheduleNextProbe(timer clock.Timer, wait time.Duration) {
+	if !timer.Stop() {
+		select {
+		case <-timer.C():
+		default:
+		}
+	}
+	if wait <= 0 {
+		h.Enqueue()
+		return
+	}
+	timer.Reset(wait)
+}
+
+// validateProbes returns an error if the probe loop cannot be started.
+func (h *AppHealth) validateProbes() error {
+	if h.closed.Load() {
+		return ErrClosed
+	}
+
+	return h.Validate()
+}
+
+// Validate returns an error if the probe function or the configuration are not valid for running probes.
+// This allows catching misconfigurations at startup rather than when the first probe runs.
+func (h *AppHealth) Validate() error {
+	if h.probeFn == nil {
+		return errors.New("cannot start probes with nil probe function")
+	}
+
+	return validateConfig(h.config)
+}
+
+// validateConfig returns an error if the configuration is not valid for running probes.
+func validateConfig(c config.AppHealthConfig) error {
+	if c.ProbeInterval <= 0 {
+		return errors.New("probe interval must be larger than 0")
+	}
+	if c.ProbeTimeout > c.ProbeInterval {
+		return errors.New("app health checks probe timeouts must be smaller than probe intervals")
+	}
+	if c.ProbeTimeoutHeadroom < 0 || c.ProbeTimeoutHeadroom >= 1 {
+		return errors.New("app health checks probe timeout headroom must be at least 0 and less than 1")
+	}
+	// A timeout equal to the interval is allowed by default, but then a probe that times out is immediately followed by the next one
+	if maxTimeout := time.Duration(float64(c.ProbeInterval) * (1 - c.ProbeTimeoutHeadroom)); c.ProbeTimeoutHeadroom > 0 && c.ProbeTimeout > maxTimeout {
+		return fmt.Errorf("app health checks probe timeouts must be at most %v to leave a headroom of %v of the probe interval", maxTimeout, c.ProbeTimeoutHeadroom)
+	}
+	if c.Threshold < 0 || c.Threshold > config.AppHealthConfigMaxThreshold {
+		return fmt.Errorf("app health checks threshold must be between 0 and %d", config.AppHealthConfigMaxThreshold)
+	}
+	if c.ConfirmationProbes < 0 {
+		return errors.New("app health checks confirmation probes must not be negative")
+	}
+	if c.StartupBurst < 0 {
+		return errors.New("app health checks startup burst must not be negative")
+	}
+	for i, w := range c.MaintenanceSchedule {
+		if w.Start < 0 || w.Start >= 24*time.Hour {
+			return fmt.Errorf("app health checks maintenance window %d must start within the day", i)
+		}
+		if w.Duration <= 0 || w.Duration > 24*time.Hour {
+			return fmt.Errorf("app health checks maintenance window %d must last between 0 and 24h", i)
+		}
+	}
+
 	return nil
 }
 
+// loopInterval returns the interval until the next probe: StartupBurstInterval while burst probes are left, and probeInterval otherwise.
+func (h *AppHealth) loopInterval(burst int) time.Duration {
+	if burst <= 0 {
+		return h.probeInterval()
+	}
+	interval := h.config.StartupBurstInterval
+	if interval <= 0 {
+		interval = config.AppHealthConfigDefaultStartupBurstInterval
+	}
+	return min(interval, h.probeInterval())
+}
+
+// probeInterval returns the interval between probes, clamped to MinProbeInterval.
+// An interval set with OverrideInterval takes precedence until it expires.
+func (h *AppHealth) probeInterval() time.Duration {
+	minInterval := h.config.MinProbeInterval
+	if minInterval == 0 {
+		minInterval = config.AppHealthConfigDefaultMinProbeInterval
+	}
+
+	if o := h.intervalOverride.Load(); o != nil {
+		if h.clock.Now().Before(o.until) {
+			return max(o.interval, minInterval)
+		}
+		if h.intervalOverride.CompareAndSwap(o, nil) {
+			log.Infof("App health probe interval override expired; reverting to %v", h.config.ProbeInterval)
+		}
+	}
+
+	interval := h.config.ProbeInterval
+	if minInterval > 0 && interval < minInterval {
+		h.clampWarnOnce.Do(func() {
+			log.Warnf("App health probe interval %v is shorter than the minimum of %v; using the minimum", interval, minInterval)
+		})
+		interval = minInterval
+	}
+	return interval
+}
+
+// historySize returns the number of transitions to keep in the history.
+func (h *AppHealth) historySize() int {
+	switch {
+	case h.config.HistorySize == 0:
+		return config.AppHealthConfigDefaultHistorySize
+	case h.config.HistorySize < 0:
+		return 0
+	default:
+		return h.config.HistorySize
+	}
+}
+
+// startupDelay returns a random duration in [0, StartupJitter].
+func (h *AppHealth) startupDelay() time.Duration {
+	if h.config.StartupJitter <= 0 {
+		return 0
+	}
+	n := int64(h.config.StartupJitter)
+	if n < math.MaxInt64 {
+		n++
+	}
+	if h.rand != nil {
+		return time.Duration(h.rand.Int64N(n))
+	}
+	return time.Duration(rand.Int64N(n))
+}
+
 // Enqueue adds a new probe request to the queue
 func (h *AppHealth) Enqueue() {
 	// The queue has a capacity of 1, so no more than one iteration can be queued up
@@ -148,78 +599,535 @@ func (h *AppHealth) Enqueue() {
 	return
 }
 
+// ManualTrigger runs a single probe synchronously, bypassing the ticker, and returns the resulting status.
+// The probe result is applied exactly like the ones performed by the probe loop.
+// This is primarily meant for tests that need to step through probe cycles deterministically, so it fails if the probe loop is running.
+func (h *AppHealth) ManualTrigger(ctx context.Context) (*Status, error) {
+	if h.closed.Load() {
+		return nil, ErrClosed
+	}
+	if h.probeFn == nil {
+		return nil, errors.New("cannot trigger probe with nil probe function")
+	}
+
+	// runningLock is held during the probe so a loop can't start, and reports can't be applied, until its result is
+	h.runningLock.Lock()
+	defer h.runningLock.Unlock()
+	if h.running > 0 {
+		return nil, errors.New("cannot trigger probe while the probe loop is running")
+	}
+
+	h.doProbe(ctx)
+	return h.GetStatus(), nil
+}
+
+// ErrClosed is returned by methods called after the object was closed.
+var ErrClosed = errors.New("app health is closed")
+
+// errProbeSuperseded is the cause of the cancellation of a probe that was in flight when the app reported its health.
+var errProbeSuperseded = errors.New("probe superseded by a report from the app")
+
 // ReportHealth is used by the runtime to report a health signal from the app.
-func (h *AppHealth) ReportHealth(status *Status) {
+// If the probe loop isn't running, the status is applied right away.
+// If ReportsCancelProbes is set, a probe in flight is canceled and its result discarded.
+// If a ReportVerifier is set, the report is rejected: use ReportSignedHealth instead.
+// Once the object is closed, reports are rejected with ErrClosed.
+func (h *AppHealth) ReportHealth(status *Status) error {
+	if v := h.reportVerifier.Load(); v != nil && *v != nil {
+		log.Warn("Rejecting unsigned app health report because a report verifier is configured")
+		return errors.New("unsigned app health report rejected: a report verifier is configured")
+	}
+	return h.reportHealth(status)
+}
+
+func (h *AppHealth) reportHealth(status *Status) error {
+	if status == nil {
+		return errors.New("app health report rejected: status is nil")
+	}
+	if h.closed.Load() {
+		return ErrClosed
+	}
+
 	// If the user wants health probes only, short-circuit here
 	if h.config.ProbeOnly {
-		return
+		return nil
+	}
+
+	if h.config.ReportsCancelProbes {
+		if cancelProbe := h.probeCancel.Load(); cancelProbe != nil {
+			(*cancelProbe)(errProbeSuperseded)
+		}
+	}
+
+	// Copy the status so we can record where it came from without altering the caller's object
+	reported := *status
+	reported.Source = StatusSourceReport
+
+	h.runningLock.Lock()
+	defer h.runningLock.Unlock()
+	if h.closed.Load() {
+		return ErrClosed
+	}
+	if h.running == 0 {
+		h.setResult(context.Background(), &reported)
+		return nil
 	}
 
 	// Channel is buffered, so make sure that this doesn't block
 	// Just in case another report is being worked on!
+	// The lock is held so the report is sent before the last loop exits, which then applies it
 	select {
-	case h.report <- status:
+	case h.report <- &reported:
 		// No action
 	default:
 		// No action
 	}
+	return nil
 }
 
-// GetStatus returns the status of the app's health
+// GetStatus returns the status of the app's health.
+// When the app is healthy, the same status is returned until the source or reason change, so the call doesn't allocate: the returned status must not be modified.
+// Its TimeUnix is the time it was first returned.
+// While a maintenance window is active, the app is reported as healthy.
 func (h *AppHealth) GetStatus() *Status {
+	if h.pinnedByMaintenance() {
+		return h.maintenanceStatus()
+	}
+	return h.actualStatus()
+}
+
+// actualStatus returns the status of the app's health, regardless of maintenance windows.
+func (h *AppHealth) actualStatus() *Status {
 	fc := h.failureCount.Load()
-	if fc >= h.config.Threshold {
-		reason := fmt.Sprintf("App health check failed %d times", fc)
-		return NewStatus(false, &reason)
+	source := h.Source()
+	if !h.determined.Load() || fc >= h.config.Threshold {
+		return h.statusFor(fc, source)
+	}
+	if reason, ok := h.checkReportAge(); !ok {
+		// Copied so only this branch allocates the reason
+		r := reason
+		status := NewStatus(false, &r)
+		status.Source = StatusSourceReport
+		return status
+	}
+	return h.healthyStatus(source)
+}
+
+// healthyStatus returns the cached healthy status for the source and the last success reason, replacing it if either changed.
+func (h *AppHealth) healthyStatus(source StatusSource) *Status {
+	var reason *string
+	if h.config.IncludeSuccessReason {
+		reason = h.lastSuccessReason.Load()
+	}
+	if s := h.healthyCache.Load(); s != nil && s.Source == source && s.Reason == reason {
+		return s
 	}
 
-	return NewStatus(true, nil)
+	s := NewStatus(true, reason)
+	s.Source = source
+	h.healthyCache.Store(s)
+	return s
+}
+
+// IsHealthy returns true if the app is currently healthy.
+func (h *AppHealth) IsHealthy() bool {
+	if h.pinnedByMaintenance() {
+		return true
+	}
+	if h.failureCount.Load() >= h.config.Threshold {
+		return false
+	}
+	_, ok := h.checkReportAge()
+	return ok
+}
+
+// HasBeenHealthy returns true if a healthy probe result or report has ever been recorded, even if the app is unhealthy now.
+// This allows consumers to tell an app that never proved functional, for which they may fail closed, from one that is temporarily unhealthy.
+// Healthy results recorded during the RecoveryGrace window count, even if the app doesn't become healthy.
+func (h *AppHealth) HasBeenHealthy() bool {
+	return h.hasBeenHealthy.Load()
+}
+
+// TimeUntilUnhealthy returns how long the app would take to become unhealthy if every probe from now on failed, based on the remaining failures before the threshold and the current probe interval.
+// Returns 0 if the app is already unhealthy.
+func (h *AppHealth) TimeUntilUnhealthy() time.Duration {
+	if !h.IsHealthy() {
+		return 0
+	}
+	remaining := h.config.Threshold - h.failureCount.Load()
+	if remaining <= 0 {
+		return 0
+	}
+	return time.Duration(remaining) * h.probeInterval()
+}
+
+// checkReportAge returns false and the reason if MaxReportAge is set and the app hasn't reported itself healthy recently enough.
+func (h *AppHealth) checkReportAge() (string, bool) {
+	if h.config.MaxReportAge <= 0 {
+		return "", true
+	}
+
+	last := h.lastReported.Load()
+	at := h.lastReportedAt.Load()
+	switch {
+	case last == nil || at == nil:
+		return "No health report received from the app", false
+	case !last.IsHealthy:
+		if last.Reason != nil {
+			return "App reported itself unhealthy: " + *last.Reason, false
+		}
+		return "App reported itself unhealthy", false
+	case h.clock.Since(*at) > h.config.MaxReportAge:
+		return fmt.Sprintf("No health report received from the app in the last %v", h.config.MaxReportAge), false
+	}
+	return "", true
+}
+
+// statusFor returns the status corresponding to the given failure count.
+func (h *AppHealth) statusFor(fc int32, source StatusSource) *Status {
+	var status *Status
+	switch {
+	case !h.determined.Load():
+		// Before any result the app is not healthy, but it hasn't failed either
+		reason := "Health not yet determined"
+		status = NewStatus(false, &reason)
+		status.Code = ReasonCodeNotYetProbed
+	case fc >= h.config.Threshold:
+		reason := h.formatReason(fc)
+		status = NewStatus(false, &reason)
+		if hint := h.lastHint.Load(); hint != nil {
+			status.RemediationHint = *hint
+		}
+	default:
+		var reason *string
+		if h.config.IncludeSuccessReason {
+			reason = h.lastSuccessReason.Load()
+		}
+		status = NewStatus(true, reason)
+	}
+	status.Source = source
+
+	return status
+}
+
+// ReasonFormatter is the signature of the function that produces the reason of an unhealthy status.
+type ReasonFormatter func(failureCount, threshold int32) string
+
+// SetReasonFormatter sets the function used to produce the reason when the app is unhealthy.
+// If nil, the default "App health check failed N times" message is used.
+func (h *AppHealth) SetReasonFormatter(fn ReasonFormatter) {
+	h.reasonFormatter.Store(&fn)
+}
+
+func (h *AppHealth) formatReason(fc int32) string {
+	if fn := h.reasonFormatter.Load(); fn != nil && *fn != nil {
+		return (*fn)(fc, h.config.Threshold)
+	}
+	return fmt.Sprintf("App health check failed %d times", fc)
+}
+
+// StatusInterceptor is the signature of the function that can rewrite a result before it's applied.
+// Returning nil drops the result.
+type StatusInterceptor func(status *Status) *Status
+
+// SetStatusInterceptor sets a function that is invoked with every probe result and report before it's applied, allowing it to be overridden or annotated.
+// For probes, it runs after the fallback probe and the latency check; for both probes and reports, it runs before the failure threshold is applied, so results it drops are not counted.
+// Passing nil removes the interceptor.
+func (h *AppHealth) SetStatusInterceptor(fn StatusInterceptor) {
+	h.interceptor.Store(&fn)
+}
+
+// intercept invokes the StatusInterceptor, if any, returning nil if the result is dropped.
+func (h *AppHealth) intercept(status *Status) *Status {
+	fn := h.interceptor.Load()
+	if fn == nil || *fn == nil {
+		return status
+	}
+	source := status.Source
+	status = (*fn)(status)
+	if status == nil {
+		log.Debug("App health result dropped by the status interceptor")
+		return nil
+	}
+	// The source is always where the result came from
+	status.Source = source
+	return status
+}
+
+// Source returns where the most recent health result came from.
+func (h *AppHealth) Source() StatusSource {
+	//nolint:gosec
+	return StatusSource(h.lastSource.Load())
 }
 
 // Performs a health probe.
 // Should be invoked in a background goroutine.
 func (h *AppHealth) doProbe(parentCtx context.Context) {
+	// Probes run on every interval, even if their results don't change the status, so windows starting and ending are noticed here too
+	h.checkMaintenance(parentCtx)
+
 	ctx, cancel := context.WithTimeout(parentCtx, h.config.ProbeTimeout)
 	defer cancel()
 
+	if h.config.ReportsCancelProbes {
+		var cancelProbe context.CancelCauseFunc
+		ctx, cancelProbe = context.WithCancelCause(ctx)
+		h.probeCancel.Store(&cancelProbe)
+		defer func() {
+			h.probeCancel.Store(nil)
+			cancelProbe(nil)
+		}()
+	}
+
+	start := h.clock.Now()
 	status, err := h.probeFn(ctx)
+	end := h.clock.Now()
+	latency := end.Sub(start)
+	h.lastProbeAt.Store(&end)
+	recordProbeOutcome(status, err)
+	if h.latencies != nil {
+		h.latencies.record(latency)
+	}
+	if err != nil && !errors.Is(context.Cause(ctx), errProbeSuperseded) {
+		if fallback := h.fallbackProbeFn.Load(); fallback != nil && *fallback != nil {
+			log.Warnf("App health probe could not complete with error: %s; using fallback probe", h.limitReason(err.Error()))
+			// The primary often fails because its context expired, so the fallback gets a timeout of its own
+			fallbackCtx, fallbackCancel := context.WithTimeout(parentCtx, h.config.ProbeTimeout)
+			status, err = (*fallback)(fallbackCtx)
+			fallbackCancel()
+			log.Debug("App health probe result recorded from fallback probe")
+		}
+	}
+
+	// A report received while the probe was in flight is authoritative
+	if errors.Is(context.Cause(ctx), errProbeSuperseded) {
+		log.Debug("App health probe canceled by a report from the app; ignoring result")
+		return
+	}
+
+	// If the parent context was canceled while the probe was in flight (e.g. during shutdown), the failure says nothing about the app's health, so don't record it
+	if (err != nil || !status.IsHealthy) && errors.Is(parentCtx.Err(), context.Canceled) {
+		log.Debug("App health probe interrupted by context cancellation; ignoring result")
+		return
+	}
+
 	if err != nil {
-		reason := fmt.Sprintf("Probe error: %v", err)
-		h.setResult(parentCtx, NewStatus(false, &reason))
-		log.Errorf("App health probe could not complete with error: %v", err)
+		h.lastDetails.Store(nil)
+		status = NewStatusWithCause("Probe error", err)
+		status.Source = StatusSourceProbe
+		h.truncateReason(status)
+		if status = h.intercept(status); status != nil && h.confirmTransition(parentCtx, status) {
+			h.setResult(parentCtx, status)
+		}
+		h.emitProbeNDJSON(status)
+		if h.isQuiet() {
+			log.Debugf("App health probe could not complete with error: %s", h.limitReason(err.Error()))
+		} else {
+			log.Errorf("App health probe could not complete with error: %s", h.limitReason(err.Error()))
+		}
 		return
 	}
 
-	// Only report if the status has changed
-	currentStatus := h.GetStatus()
-	if currentStatus.IsHealthy != status.IsHealthy {
+	status.Source = StatusSourceProbe
+	h.applyRetryAfter(status.RetryAfter)
+	if threshold := h.config.LatencyUnhealthyThreshold; threshold > 0 && status.IsHealthy && latency > threshold {
+		reason := fmt.Sprintf("App health probe succeeded but took %v, more than the threshold of %v", latency, threshold)
+		log.Debug(reason)
+		status.IsHealthy = false
+		status.Reason = &reason
+		status.Code = ReasonCodeSlowResponse
+	}
+	h.truncateReason(status)
+	if status = h.intercept(status); status == nil {
+		return
+	}
+	if status.IsHealthy {
+		h.lastSuccessReason.Store(status.Reason)
+	}
+	// Details are recorded even if the status is unchanged, as individual checks may have changed
+	if status.Details != nil {
+		h.lastDetails.Store(&status.Details)
+	} else {
+		h.lastDetails.Store(nil)
+	}
+
+	// Only report if the status has changed, or if a failure needs to cancel the recovery grace window
+	// Compared with the actual status, so successes during a maintenance window still reset the failures
+	currentStatus := h.actualStatus()
+	if currentStatus.IsHealthy != status.IsHealthy || h.recoveryPending() {
 		log.Debug("App health probe detected status change - health probe successful: " + strconv.FormatBool(status.IsHealthy))
-		h.setResult(parentCtx, status)
+		if h.confirmTransition(parentCtx, status) {
+			h.setResult(parentCtx, status)
+		}
 	} else {
 		log.Debug("App health probe status is unchanged - health probe successful: %v", strconv.FormatBool(status.IsHealthy))
 	}
+	h.emitProbeNDJSON(status)
+}
+
+// recordProbeOutcome records the raw result of the probe function, before the fallback probe and the failure threshold are applied.
+func recordProbeOutcome(status *Status, err error) {
+	switch {
+	case err != nil:
+		diag.DefaultMonitoring.AppHealthProbeOutcome("error")
+	case status.IsHealthy:
+		diag.DefaultMonitoring.AppHealthProbeOutcome("healthy")
+	default:
+		diag.DefaultMonitoring.AppHealthProbeOutcome("unhealthy")
+	}
+}
+
+// applyRetryAfter delays the next probe according to the hint returned by the probe, if enabled.
+// The delay is capped at MaxRetryAfter and has up to 10% of jitter added so multiple sidecars don't retry in lockstep.
+func (h *AppHealth) applyRetryAfter(retryAfter time.Duration) {
+	if h.config.MaxRetryAfter <= 0 || retryAfter <= 0 {
+		h.probeNotBefore.Store(nil)
+		return
+	}
+
+	delay := min(retryAfter, h.config.MaxRetryAfter)
+	if j := int64(delay / 10); j > 0 {
+		if h.rand != nil {
+			delay += time.Duration(h.rand.Int64N(j))
+		} else {
+			delay += time.Duration(rand.Int64N(j))
+		}
+	}
+	log.Debugf("App health probe returned a Retry-After hint; delaying next probe by %v", delay)
+	nb := h.clock.Now().Add(delay)
+	h.probeNotBefore.Store(&nb)
 }
 
 func (h *AppHealth) setResult(ctx context.Context, status *Status) {
-	h.lastReport.Store(h.clock.Now().UnixMicro())
+	h.resultLock.RLock()
+	defer h.resultLock.RUnlock()
+	if h.closed.Load() {
+		log.Debug("Ignoring app health result because app health is closed")
+		h.traceDecision(status, -1, -1, false, "ignored: closed")
+		return
+	}
+
+	if h.draining.Load() {
+		log.Debug("Ignoring app health result because the app is shutting down")
+		h.traceDecision(status, -1, -1, false, "ignored: shutting down")
+		return
+	}
+
+	// Reports don't go through doProbe, so their reasons are bounded here
+	h.truncateReason(status)
+
+	now := h.clock.Now()
+	h.updateMaintenance(ctx, now)
+	h.lastReport.Store(now.UnixMicro())
+	h.lastReportAt.Store(&now)
+	// Set after the transition below is computed, so the initial state is reported as not yet determined
+	defer h.determined.Store(true)
+
+	if status.Source == StatusSourceReport {
+		if status = h.intercept(status); status == nil {
+			return
+		}
+		prev := h.lastReported.Swap(status)
+		h.lastReportedAt.Store(&now)
+
+		// When recent reports are required, they are combined with the probe results in GetStatus rather than counted
+		if h.config.MaxReportAge > 0 {
+			h.traceDecision(status, -1, -1, false, "tracked: reports are required separately")
+			return
+		}
+
+		// Repeated reports of the same status are treated as heartbeats
+		if h.config.CoalesceDuplicateReports && prev != nil && sameReport(prev, status) {
+			log.Debug("App reported the same health status as the previous report; treating it as a heartbeat")
+			h.traceDecision(status, -1, -1, false, "ignored: duplicate report")
+			return
+		}
+
+		// When probes are the source of truth, the app reporting itself healthy doesn't clear failures detected by probes
+		if status.IsHealthy && !h.reportsOverrideProbes() && h.failureCount.Load() > 0 {
+			log.Debug("Ignoring healthy report from the app because failures have been detected")
+			h.traceDecision(status, -1, -1, false, "ignored: reports don't override probe failures")
+			return
+		}
+	}
+
+	defer func() {
+		h.failureSamples.record(h.failureCount.Load())
+	}()
+
+	//nolint:gosec
+	prevSource := StatusSource(h.lastSource.Swap(uint32(status.Source)))
+
+	if !status.IsHealthy && status.RemediationHint != "" {
+		h.lastHint.Store(&status.RemediationHint)
+	} else {
+		h.lastHint.Store(nil)
+	}
 
 	if status.IsHealthy {
+		h.hasBeenHealthy.Store(true)
+		h.lastSuccessReason.Store(status.Reason)
+
+		// The app is already reported as healthy during a maintenance window, so the failures are reset without a transition
+		if h.maintenance.Load() {
+			prev := h.failureCount.Swap(0)
+			h.traceDecision(status, prev, 0, false, "reset: maintenance window")
+			return
+		}
+
+		// The app stays unhealthy until the recovery grace window is over
+		if h.config.RecoveryGrace > 0 {
+			if fc := h.failureCount.Load(); fc >= h.config.Threshold {
+				h.startRecovery(ctx, status, prevSource)
+				h.traceDecision(status, fc, fc, false, "pending: recovery grace")
+				return
+			}
+		}
+
 		// Reset the failure count
 		// If the previous value was >= threshold, we need to report a health change
 		prev := h.failureCount.Swap(0)
 		if prev >= h.config.Threshold {
-			log.Info("App entered healthy status")
-			if h.changeCb != nil {
-				h.wg.Add(1)
-				go func() {
-					defer h.wg.Done()
-					h.changeCb(ctx, status)
-				}()
-			}
+			id := h.transitionSeq.Add(1)
+			log.Infof("App entered healthy status (transition %d)", id)
+			notified := h.transition(ctx, TransitionEvent{
+				ID:           id,
+				At:           now,
+				From:         h.statusFor(prev, prevSource),
+				To:           status,
+				FailureCount: 0,
+			})
+			h.traceDecision(status, prev, 0, notified, "transition: healthy")
+		} else {
+			h.traceDecision(status, prev, 0, false, "reset: already healthy")
 		}
 		return
 	}
 
+	h.cancelRecovery()
+
+	if h.isLenient(now) {
+		log.Debug("App health failure not counted because lenient mode is active")
+		fc := h.failureCount.Load()
+		h.traceDecision(status, fc, fc, false, "ignored: lenient mode")
+		return
+	}
+
+	if h.maintenance.Load() {
+		log.Debug("App health failure not counted because a maintenance window is active")
+		fc := h.failureCount.Load()
+		h.traceDecision(status, fc, fc, false, "ignored: maintenance window")
+		return
+	}
+
+	// Permanent failures won't resolve by retrying, so they skip the remaining failures before the threshold
+	if h.config.FailFastOnPermanent && status.FailureClass == FailureClassPermanent {
+		if fc := h.failureCount.Load(); fc < h.config.Threshold-1 {
+			log.Debug("App health failure is permanent; not waiting for the threshold")
+			h.failureCount.CompareAndSwap(fc, h.config.Threshold-1)
+		}
+	}
+
 	// Increment failure count atomically and get the new value
 	newFailures := h.failureCount.Add(1)
 
@@ -231,26 +1139,275 @@ func (h *AppHealth) setResult(ctx context.Context, status *Status) {
 
 	// Notify when crossing threshold
 	if newFailures == h.config.Threshold {
+		id := h.transitionSeq.Add(1)
+		var reason string
 		if status.Reason != nil {
-			log.Warn("App entered un-healthy status: " + *status.Reason)
+			reason = *status.Reason
 		} else {
-			log.Warn("App entered un-healthy status")
+			reason = h.formatReason(newFailures)
+		}
+		if status.RemediationHint != "" {
+			reason += " (hint: " + status.RemediationHint + ")"
 		}
-		if h.changeCb != nil {
-			h.wg.Add(1)
-			go func() {
-				defer h.wg.Done()
-				h.changeCb(ctx, status)
-			}()
+		// Failures are expected while the app is starting, so they're not logged as warnings during the quiet period
+		if h.isQuiet() {
+			log.Debugf("App entered un-healthy status (transition %d): %s", id, reason)
+		} else {
+			log.Warnf("App entered un-healthy status (transition %d): %s", id, reason)
 		}
+		notified := h.transition(ctx, TransitionEvent{
+			ID:           id,
+			At:           now,
+			From:         h.statusFor(newFailures-1, prevSource),
+			To:           status,
+			FailureCount: newFailures,
+		})
+		h.traceDecision(status, newFailures-1, newFailures, notified, "transition: unhealthy")
+		return
+	}
+
+	if newFailures < h.config.Threshold {
+		h.traceDecision(status, newFailures-1, newFailures, false, "counted: below threshold")
+	} else {
+		h.traceDecision(status, newFailures-1, newFailures, false, "counted: already unhealthy")
+	}
+}
+
+// traceDecision logs how a result was applied, if TraceDecisions is enabled.
+// Counts are -1 when the result was ignored before the failure count was read.
+func (h *AppHealth) traceDecision(status *Status, prevCount, newCount int32, notified bool, decision string) {
+	if !h.config.TraceDecisions {
+		return
+	}
+
+	fields := map[string]any{
+		"healthy":      status.IsHealthy,
+		"source":       status.Source.String(),
+		"prevFailures": prevCount,
+		"newFailures":  newCount,
+		"threshold":    h.config.Threshold,
+		"notified":     notified,
+		"decision":     decision,
+	}
+	if status.Reason != nil {
+		fields["reason"] = *status.Reason
 	}
+	log.WithFields(fields).Info("App health decision")
+}
+
+// EnterLenientMode stops failures from counting towards the threshold for the duration d, for example while the app is reloading its configuration.
+// Failures are still recorded as the last result, and successes still reset the failure count.
+// Normal counting resumes automatically after the duration; calling it again replaces the current window.
+func (h *AppHealth) EnterLenientMode(d time.Duration) {
+	until := h.clock.Now().Add(d)
+	h.lenientUntil.Store(&until)
+	log.Infof("App health lenient mode enabled for %v", d)
+}
+
+type intervalOverride struct {
+	interval time.Duration
+	until    time.Time
+}
+
+// OverrideInterval probes the app every interval for the duration d, for example to speed up probing while debugging, and then reverts to ProbeInterval.
+// The override takes effect right away and ends at the first probe scheduled after d has elapsed; calling it again replaces the current override.
+// The interval is still clamped to MinProbeInterval.
+func (h *AppHealth) OverrideInterval(interval, d time.Duration) error {
+	if interval <= 0 {
+		return errors.New("probe interval override must be larger than 0")
+	}
+	if d <= 0 {
+		return errors.New("probe interval override duration must be larger than 0")
+	}
+
+	h.intervalOverride.Store(&intervalOverride{
+		interval: interval,
+		until:    h.clock.Now().Add(d),
+	})
+	log.Infof("App health probe interval overridden to %v for %v", interval, d)
+
+	select {
+	case h.intervalCh <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func (h *AppHealth) isLenient(now time.Time) bool {
+	until := h.lenientUntil.Load()
+	return until != nil && now.Before(*until)
+}
+
+// isQuiet returns true during the StartupQuietPeriod after the probe loop first started.
+func (h *AppHealth) isQuiet() bool {
+	until := h.quietUntil.Load()
+	return until != nil && h.clock.Now().Before(*until)
+}
+
+// sameReport returns true if two statuses reported by the app have the same health and reason.
+func sameReport(a, b *Status) bool {
+	return a.IsHealthy == b.IsHealthy && equalReason(a.Reason, b.Reason)
+}
+
+// TimeSinceLastReport returns the time elapsed since the last probe result or report was recorded.
+// The value is never negative, even if the wall clock moves backwards.
+// Returns false if no result has been recorded yet.
+func (h *AppHealth) TimeSinceLastReport() (time.Duration, bool) {
+	lr := h.lastReportAt.Load()
+	if lr == nil {
+		return 0, false
+	}
+	return max(h.clock.Since(*lr), 0), true
+}
+
+// reportsOverrideProbes returns true if a healthy status reported by the app resets the failure count.
+func (h *AppHealth) reportsOverrideProbes() bool {
+	return h.config.ReportsOverrideProbes == nil || *h.config.ReportsOverrideProbes
+}
+
+// fireInitialTransition returns true if the change callback is invoked for the first transition.
+func (h *AppHealth) fireInitialTransition() bool {
+	return h.config.FireInitialTransition == nil || *h.config.FireInitialTransition
 }
 
+// LastReported returns the last status reported by the app via ReportHealth, or nil if the app hasn't reported any.
+func (h *AppHealth) LastReported() *Status {
+	return h.lastReported.Load()
+}
+
+// transition records a change in the app's health and notifies the callback.
+// The transition's ID is added to the context passed to the callback.
+// Returns false if the callback was not notified because this is the initial transition and FireInitialTransition is disabled.
+func (h *AppHealth) transition(ctx context.Context, event TransitionEvent) bool {
+	h.recordTransition(event)
+	if event.To.IsHealthy {
+		h.fireOnceHealthy(ctx)
+	}
+	if !h.transitioned.Swap(true) && !h.fireInitialTransition() {
+		log.Debug("Not invoking the change callback for the initial app health transition")
+		return false
+	}
+	h.notifyChange(withTransitionID(ctx, event.ID), event.To)
+	h.dispatchTransition(event)
+	return true
+}
+
+// recordTransition sends the transition to the audit sink, the circuit breaker, the history, and the ready barrier.
+func (h *AppHealth) recordTransition(event TransitionEvent) {
+	if sink := h.auditSink.Load(); sink != nil && *sink != nil {
+		(*sink).Record(event)
+	}
+	h.logTransitionSlog(event)
+	h.emitTransitionNDJSON(event)
+	if b := h.breakerBridge(); b != nil {
+		b.OnHealthTransition(event.To.IsHealthy)
+	}
+	h.history.record(event)
+	if b := h.barrier.Load(); b != nil && event.To.IsHealthy {
+		b.open()
+	}
+	h.persist(event)
+}
+
+// notifyChange invokes the change callback, subject to rate limiting if configured.
+func (h *AppHealth) notifyChange(ctx context.Context, status *Status) {
+	if h.cbLimiter != nil {
+		h.cbLimiter.notify(ctx, status)
+		return
+	}
+	h.dispatchChange(ctx, status)
+}
+
+// dispatchChange invokes the change callback, if any, in a background goroutine.
+func (h *AppHealth) dispatchChange(ctx context.Context, status *Status) {
+	cb := h.changeCb.Load()
+	if cb == nil || *cb == nil {
+		return
+	}
+
+	if h.cbPool != nil {
+		h.cbPool.dispatch(ctx, *cb, status)
+		return
+	}
+
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+		defer h.recoverCallback()
+		(*cb)(ctx, status)
+	}()
+}
+
+// dispatchTransition invokes the transition callback in background.
+func (h *AppHealth) dispatchTransition(event TransitionEvent) {
+	cb := h.transitionCb.Load()
+	if cb == nil || *cb == nil {
+		return
+	}
+
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+		defer h.recoverCallback()
+		(*cb)(event)
+	}()
+}
+
+// recoverCallback recovers from a panic in a callback, counting it as a callback failure.
+// It must be deferred by the goroutine running the callback.
+func (h *AppHealth) recoverCallback() {
+	if r := recover(); r != nil {
+		h.callbackFailures.Add(1)
+		log.Errorf("App health callback panicked: %v", r)
+	}
+}
+
+// CallbackFailureCount returns the number of callbacks that panicked since the object was created or ResetCallbackFailures was last called.
+// A rising count indicates a broken integration.
+func (h *AppHealth) CallbackFailureCount() int64 {
+	return h.callbackFailures.Load()
+}
+
+// ResetCallbackFailures sets the count of callback failures back to zero, returning the previous count.
+func (h *AppHealth) ResetCallbackFailures() int64 {
+	return h.callbackFailures.Swap(0)
+}
+
+// Close stops the probes and releases all subscribers: watch channels are closed, heartbeats are stopped, and the change and transition callbacks are unregistered.
+// If NotifyOnClose is set and the app was healthy, an unhealthy "shutting down" transition is first delivered to the change callback, the audit sink, and watchers.
+// Close blocks until all callbacks and subscriber goroutines have returned.
 func (h *AppHealth) Close() error {
 	defer h.wg.Wait()
 	if h.closed.CompareAndSwap(false, true) {
+		// Shutdown notifies subscribers itself before draining
+		if h.config.NotifyOnClose {
+			h.startDraining(context.Background())
+		}
 		close(h.closeCh)
+		// Wait for results being applied; setResult discards all the ones that follow
+		h.resultLock.Lock()
+		//nolint:staticcheck
+		h.resultLock.Unlock()
+		if h.cbLimiter != nil {
+			h.cbLimiter.stop()
+		}
+		h.cancelRecovery()
+		h.flushPersist()
+
+		h.wg.Wait()
+		// Reports queued for loops that were stopped by other means are answered too
+		h.runningLock.Lock()
+		h.applySyncReports(context.Background(), h.takeSyncReports())
+		h.runningLock.Unlock()
+		h.changeCb.Store(nil)
+		h.transitionCb.Store(nil)
+		close(h.done)
 	}
 
 	return nil
 }
+
+// Done returns a channel that is closed once the object is closed and all callbacks and subscriber goroutines have returned.
+func (h *AppHealth) Done() <-chan struct{} {
+	return h.done
+}
@@ -14,8 +14,16 @@ limitations under the License.
 package apphealth
 
 import (
+	"bytes"
+	"cmp"
 	"context"
+	"errors"
+	"fmt"
 	"math"
+	"math/rand/v2"
+	"os"
+	"slices"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -23,9 +31,11 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
 	clocktesting "k8s.io/utils/clock/testing"
 
 	"github.com/dapr/dapr/pkg/config"
+	"github.com/dapr/kit/ptr"
 )
 
 func TestAppHealth_setResult(t *testing.T) {
@@ -266,3 +276,1780 @@ func Test_StartProbes(t *testing.T) {
 		}
 	})
 }
+
+func TestAppHealth_StartupBurst(t *testing.T) {
+	newHealth := func(t *testing.T, healthyAfter int64) (*AppHealth, *clocktesting.FakeClock, *atomic.Int64) {
+		var probeCalls atomic.Int64
+		h := New(config.AppHealthConfig{
+			ProbeInterval:        10 * time.Second,
+			ProbeTimeout:         time.Second,
+			Threshold:            1,
+			StartupBurst:         3,
+			StartupBurstInterval: 100 * time.Millisecond,
+		}, func(context.Context) (*Status, error) {
+			return NewStatus(probeCalls.Add(1) > healthyAfter, nil), nil
+		})
+		clock := clocktesting.NewFakeClock(time.Now())
+		h.clock = clock
+		t.Cleanup(func() { h.Close() })
+		require.NoError(t, h.StartProbes(t.Context()))
+		assert.Eventually(t, clock.HasWaiters, time.Second, time.Microsecond)
+		return h, clock, &probeCalls
+	}
+	// step advances the clock and waits for the probe loop to run the n-th probe and re-arm its timer
+	step := func(t *testing.T, clock *clocktesting.FakeClock, probeCalls *atomic.Int64, d time.Duration, n int64) {
+		t.Helper()
+		clock.Step(d)
+		assert.Eventually(t, func() bool {
+			return probeCalls.Load() == n
+		}, time.Second, time.Microsecond)
+		assert.Eventually(t, clock.HasWaiters, time.Second, time.Microsecond)
+	}
+
+	t.Run("burst ends when the app is healthy", func(t *testing.T) {
+		h, clock, probeCalls := newHealth(t, 1)
+		step(t, clock, probeCalls, 100*time.Millisecond, 1)
+		assert.False(t, h.IsHealthy())
+		step(t, clock, probeCalls, 100*time.Millisecond, 2)
+		assert.True(t, h.IsHealthy())
+
+		// Back to the normal interval
+		step(t, clock, probeCalls, 100*time.Millisecond, 2)
+		step(t, clock, probeCalls, 10*time.Second-100*time.Millisecond, 3)
+	})
+
+	t.Run("burst is exhausted", func(t *testing.T) {
+		h, clock, probeCalls := newHealth(t, 100)
+		for i := range int64(3) {
+			step(t, clock, probeCalls, 100*time.Millisecond, i+1)
+		}
+		assert.False(t, h.IsHealthy())
+		step(t, clock, probeCalls, 100*time.Millisecond, 3)
+		step(t, clock, probeCalls, 10*time.Second-100*time.Millisecond, 4)
+	})
+}
+
+func Test_StartupJitter(t *testing.T) {
+	newHealth := func(probeCalls *atomic.Int64) (*AppHealth, *clocktesting.FakeClock) {
+		h := New(config.AppHealthConfig{
+			ProbeInterval: time.Second,
+			StartupJitter: 10 * time.Second,
+			Threshold:     1,
+		}, func(context.Context) (*Status, error) {
+			probeCalls.Add(1)
+			return NewStatus(true, nil), nil
+		})
+		clock := clocktesting.NewFakeClock(time.Now())
+		h.clock = clock
+		h.rand = rand.New(rand.NewPCG(1, 2))
+		return h, clock
+	}
+
+	t.Run("first probe is delayed by the seeded jitter", func(t *testing.T) {
+		var probeCalls atomic.Int64
+		h, clock := newHealth(&probeCalls)
+		t.Cleanup(func() { h.Close() })
+
+		// The same seed yields the same delay
+		delay := time.Duration(rand.New(rand.NewPCG(1, 2)).Int64N(int64(10*time.Second) + 1))
+		require.Greater(t, delay, time.Second)
+
+		require.NoError(t, h.StartProbes(t.Context()))
+		assert.Eventually(t, clock.HasWaiters, time.Second, time.Microsecond)
+
+		// Before the jitter elapses, no ticker has been started so no probe happens
+		clock.Step(delay - time.Nanosecond)
+		time.Sleep(10 * time.Millisecond)
+		assert.Equal(t, int64(0), probeCalls.Load())
+
+		// Once the delay elapses, the ticker starts and probes on the interval
+		clock.Step(time.Nanosecond)
+		assert.Eventually(t, clock.HasWaiters, time.Second, time.Microsecond)
+		assert.Equal(t, int64(0), probeCalls.Load())
+		clock.Step(time.Second)
+		assert.Eventually(t, func() bool {
+			return probeCalls.Load() == 1
+		}, time.Second, time.Microsecond)
+	})
+
+	t.Run("close during startup delay returns promptly", func(t *testing.T) {
+		var probeCalls atomic.Int64
+		h, clock := newHealth(&probeCalls)
+
+		require.NoError(t, h.StartProbes(t.Context()))
+		assert.Eventually(t, clock.HasWaiters, time.Second, time.Microsecond)
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			h.Close()
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Millisecond * 100):
+			require.Fail(t, "Close didn't return in time")
+		}
+		assert.Equal(t, int64(0), probeCalls.Load())
+	})
+}
+
+func TestAppHealth_SwapHealthChange(t *testing.T) {
+	h := New(config.AppHealthConfig{
+		Threshold: 1,
+	}, nil)
+
+	assert.Nil(t, h.SwapHealthChange(nil))
+
+	var calls []string
+	var lock sync.Mutex
+	record := func(name string) ChangeCallback {
+		return func(ctx context.Context, status *Status) {
+			lock.Lock()
+			defer lock.Unlock()
+			calls = append(calls, name)
+		}
+	}
+
+	h.OnHealthChange(record("inner"))
+
+	// Decorate the existing callback
+	inner := h.SwapHealthChange(nil)
+	require.NotNil(t, inner)
+	old := h.SwapHealthChange(func(ctx context.Context, status *Status) {
+		record("outer")(ctx, status)
+		inner(ctx, status)
+	})
+	assert.Nil(t, old)
+
+	h.setResult(t.Context(), NewStatus(true, nil))
+	h.Close()
+
+	assert.Equal(t, []string{"outer", "inner"}, calls)
+
+	t.Run("swapping concurrently with transitions is safe", func(t *testing.T) {
+		h := New(config.AppHealthConfig{
+			Threshold: 1,
+		}, nil)
+		var wg sync.WaitGroup
+		for i := range 10 {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				h.SwapHealthChange(func(context.Context, *Status) {})
+			}()
+			go func() {
+				defer wg.Done()
+				h.setResult(t.Context(), NewStatus(i%2 == 0, nil))
+			}()
+		}
+		wg.Wait()
+		h.Close()
+	})
+}
+
+func TestAppHealth_OnTransition(t *testing.T) {
+	h := New(config.AppHealthConfig{
+		Threshold: 2,
+	}, nil)
+
+	var events []TransitionEvent
+	var lock sync.Mutex
+	h.OnTransition(func(event TransitionEvent) {
+		lock.Lock()
+		defer lock.Unlock()
+		events = append(events, event)
+	})
+	var changes atomic.Int32
+	h.OnHealthChange(func(context.Context, *Status) {
+		changes.Add(1)
+	})
+
+	h.setResult(t.Context(), NewStatus(true, nil))
+	reason := "failed"
+	h.setResult(t.Context(), NewStatus(false, &reason))
+	h.setResult(t.Context(), NewStatus(false, &reason))
+	h.Close()
+
+	// The existing change callback is still invoked for the same transitions
+	assert.Equal(t, int32(2), changes.Load())
+
+	require.Len(t, events, 2)
+	slices.SortFunc(events, func(a, b TransitionEvent) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	assert.False(t, events[0].From.IsHealthy)
+	assert.Equal(t, ReasonCodeNotYetProbed, events[0].From.Code)
+	assert.True(t, events[0].To.IsHealthy)
+	assert.Equal(t, int32(0), events[0].FailureCount)
+
+	assert.True(t, events[1].From.IsHealthy)
+	assert.False(t, events[1].To.IsHealthy)
+	assert.Equal(t, &reason, events[1].To.Reason)
+	assert.Equal(t, int32(2), events[1].FailureCount)
+	assert.False(t, events[1].At.Before(events[0].At))
+}
+
+func TestAppHealth_OnceHealthy(t *testing.T) {
+	t.Run("fires once on the first healthy transition", func(t *testing.T) {
+		h := New(config.AppHealthConfig{
+			Threshold:             1,
+			FireInitialTransition: ptr.Of(false),
+		}, nil)
+
+		var calls atomic.Int32
+		h.OnceHealthy(func(context.Context) {
+			calls.Add(1)
+		})
+
+		// The initial transition isn't delivered to the change callback, but still fires the callback
+		h.setResult(t.Context(), NewStatus(true, nil))
+		h.setResult(t.Context(), NewStatus(false, nil))
+		h.setResult(t.Context(), NewStatus(true, nil))
+		h.Close()
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("fires right away if already healthy", func(t *testing.T) {
+		h := New(config.AppHealthConfig{
+			Threshold: 1,
+		}, nil)
+		h.setResult(t.Context(), NewStatus(true, nil))
+		h.setResult(t.Context(), NewStatus(false, nil))
+
+		var calls atomic.Int32
+		h.OnceHealthy(func(context.Context) {
+			calls.Add(1)
+		})
+		h.Close()
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("registered concurrently with the first transition", func(t *testing.T) {
+		for range 50 {
+			h := New(config.AppHealthConfig{
+				Threshold: 1,
+			}, nil)
+			var calls atomic.Int32
+			var wg sync.WaitGroup
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				h.OnceHealthy(func(context.Context) {
+					calls.Add(1)
+				})
+			}()
+			go func() {
+				defer wg.Done()
+				h.setResult(t.Context(), NewStatus(true, nil))
+			}()
+			wg.Wait()
+			h.Close()
+			require.Equal(t, int32(1), calls.Load())
+		}
+	})
+}
+
+func TestAppHealth_GetStatusAllocations(t *testing.T) {
+	h := New(config.AppHealthConfig{
+		Threshold: 1,
+	}, nil)
+	t.Cleanup(func() { h.Close() })
+	h.setResult(t.Context(), NewStatus(true, nil))
+
+	first := h.GetStatus()
+	assert.True(t, first.IsHealthy)
+	assert.Same(t, first, h.GetStatus())
+	assert.Zero(t, testing.AllocsPerRun(100, func() {
+		h.GetStatus()
+	}))
+
+	// A change of source replaces the cached status
+	status := NewStatus(true, nil)
+	status.Source = StatusSourceProbe
+	h.setResult(t.Context(), status)
+	second := h.GetStatus()
+	assert.NotSame(t, first, second)
+	assert.Equal(t, StatusSourceProbe, second.Source)
+
+	h.setResult(t.Context(), NewStatus(false, nil))
+	assert.False(t, h.GetStatus().IsHealthy)
+}
+
+func BenchmarkAppHealth_GetStatus(b *testing.B) {
+	h := New(config.AppHealthConfig{
+		Threshold: 1,
+	}, nil)
+	b.Cleanup(func() { h.Close() })
+	h.setResult(b.Context(), NewStatus(true, nil))
+
+	b.ReportAllocs()
+	for b.Loop() {
+		h.GetStatus()
+	}
+}
+
+func TestAppHealth_TimeUntilUnhealthy(t *testing.T) {
+	h := New(config.AppHealthConfig{
+		ProbeInterval: 5 * time.Second,
+		Threshold:     3,
+	}, nil)
+	t.Cleanup(func() { h.Close() })
+
+	// Not healthy before the first result
+	assert.Zero(t, h.TimeUntilUnhealthy())
+
+	h.setResult(t.Context(), NewStatus(true, nil))
+	assert.Equal(t, 15*time.Second, h.TimeUntilUnhealthy())
+
+	h.setResult(t.Context(), NewStatus(false, nil))
+	assert.Equal(t, 10*time.Second, h.TimeUntilUnhealthy())
+
+	// The current interval is used
+	require.NoError(t, h.OverrideInterval(time.Second, time.Minute))
+	assert.Equal(t, 2*time.Second, h.TimeUntilUnhealthy())
+
+	h.setResult(t.Context(), NewStatus(false, nil))
+	h.setResult(t.Context(), NewStatus(false, nil))
+	assert.Zero(t, h.TimeUntilUnhealthy())
+}
+
+func TestAppHealth_FailFastOnPermanent(t *testing.T) {
+	newStatus := func(class FailureClass) *Status {
+		s := NewStatus(false, nil)
+		s.FailureClass = class
+		return s
+	}
+
+	t.Run("permanent failures make the app unhealthy right away", func(t *testing.T) {
+		h := New(config.AppHealthConfig{
+			Threshold:           3,
+			FailFastOnPermanent: true,
+		}, nil)
+		t.Cleanup(func() { h.Close() })
+		h.setResult(t.Context(), NewStatus(true, nil))
+
+		// Transient failures use the threshold
+		h.setResult(t.Context(), newStatus(FailureClassTransient))
+		assert.True(t, h.IsHealthy())
+
+		h.setResult(t.Context(), newStatus(FailureClassPermanent))
+		assert.False(t, h.IsHealthy())
+		assert.Equal(t, int32(3), h.failureCount.Load())
+		require.Len(t, h.History(), 2)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		h := New(config.AppHealthConfig{
+			Threshold: 3,
+		}, nil)
+		t.Cleanup(func() { h.Close() })
+		h.setResult(t.Context(), NewStatus(true, nil))
+
+		h.setResult(t.Context(), newStatus(FailureClassPermanent))
+		assert.True(t, h.IsHealthy())
+		assert.Equal(t, int32(1), h.failureCount.Load())
+	})
+}
+
+func TestAppHealth_CallbackFailures(t *testing.T) {
+	h := New(config.AppHealthConfig{
+		Threshold: 1,
+	}, nil)
+
+	h.OnHealthChange(func(context.Context, *Status) {
+		panic("callback is broken")
+	})
+	h.OnTransition(func(TransitionEvent) {
+		panic("callback is broken")
+	})
+
+	h.setResult(t.Context(), NewStatus(true, nil))
+	assert.Eventually(t, func() bool {
+		return h.CallbackFailureCount() == 2
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, int64(2), h.Snapshot().CallbackFailures)
+
+	assert.Equal(t, int64(2), h.ResetCallbackFailures())
+	assert.Zero(t, h.CallbackFailureCount())
+
+	// Later transitions are still delivered
+	h.setResult(t.Context(), NewStatus(false, nil))
+	require.NoError(t, h.Close())
+	assert.Equal(t, int64(2), h.CallbackFailureCount())
+
+	t.Run("callback pool", func(t *testing.T) {
+		h := New(config.AppHealthConfig{
+			Threshold:             1,
+			MaxCallbackGoroutines: 1,
+		}, nil)
+		var calls atomic.Int32
+		h.OnHealthChange(func(context.Context, *Status) {
+			if calls.Add(1) == 1 {
+				panic("callback is broken")
+			}
+		})
+		h.setResult(t.Context(), NewStatus(true, nil))
+		h.setResult(t.Context(), NewStatus(false, nil))
+		require.NoError(t, h.Close())
+		assert.Equal(t, int32(2), calls.Load())
+		assert.Equal(t, int64(1), h.CallbackFailureCount())
+	})
+}
+
+func TestAppHealth_SetShouldProbe(t *testing.T) {
+	var probeCalls atomic.Int32
+	var healthy atomic.Bool
+	h := New(config.AppHealthConfig{
+		ProbeInterval: time.Second,
+		ProbeTimeout:  time.Second,
+		Threshold:     1,
+	}, func(context.Context) (*Status, error) {
+		probeCalls.Add(1)
+		return NewStatus(healthy.Load(), nil), nil
+	})
+	clock := clocktesting.NewFakeClock(time.Now())
+	h.clock = clock
+	t.Cleanup(func() { h.Close() })
+
+	var leader atomic.Bool
+	h.SetShouldProbe(leader.Load)
+	h.setResult(t.Context(), NewStatus(true, nil))
+
+	// Probes are skipped, and the status is frozen, while the gate is closed
+	require.NoError(t, h.StartProbes(t.Context()))
+	for range 3 {
+		assert.Eventually(t, clock.HasWaiters, time.Second, time.Microsecond)
+		clock.Step(time.Second)
+	}
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, int32(0), probeCalls.Load())
+	assert.True(t, h.IsHealthy())
+
+	leader.Store(true)
+	clock.Step(time.Second)
+	assert.Eventually(t, func() bool {
+		return probeCalls.Load() == 1 && !h.IsHealthy()
+	}, time.Second, time.Microsecond)
+}
+
+func TestAppHealth_ManualTrigger(t *testing.T) {
+	var probeCalls atomic.Int64
+	h := New(config.AppHealthConfig{
+		ProbeInterval: time.Second,
+		ProbeTimeout:  time.Second,
+		Threshold:     2,
+	}, func(context.Context) (*Status, error) {
+		defer probeCalls.Add(1)
+		if probeCalls.Load() == 0 {
+			return NewStatus(true, nil), nil
+		}
+		return NewStatus(false, nil), nil
+	})
+
+	var changes atomic.Int32
+	h.OnHealthChange(func(ctx context.Context, status *Status) {
+		changes.Add(1)
+	})
+
+	status, err := h.ManualTrigger(t.Context())
+	require.NoError(t, err)
+	assert.True(t, status.IsHealthy)
+	assert.Equal(t, int32(0), h.failureCount.Load())
+
+	// First failure is below the threshold
+	status, err = h.ManualTrigger(t.Context())
+	require.NoError(t, err)
+	assert.True(t, status.IsHealthy)
+	assert.Equal(t, int32(1), h.failureCount.Load())
+
+	status, err = h.ManualTrigger(t.Context())
+	require.NoError(t, err)
+	assert.False(t, status.IsHealthy)
+	assert.Equal(t, int32(2), h.failureCount.Load())
+	assert.Equal(t, int64(3), probeCalls.Load())
+
+	require.NoError(t, h.Close())
+	assert.Equal(t, int32(2), changes.Load())
+
+	_, err = h.ManualTrigger(t.Context())
+	require.Error(t, err)
+
+	t.Run("nil probe function returns error", func(t *testing.T) {
+		_, err := New(config.AppHealthConfig{}, nil).ManualTrigger(t.Context())
+		require.Error(t, err)
+	})
+
+	t.Run("fails while the probe loop is running", func(t *testing.T) {
+		var probeCalls atomic.Int64
+		h := New(config.AppHealthConfig{
+			ProbeInterval: time.Second,
+			Threshold:     1,
+		}, func(context.Context) (*Status, error) {
+			probeCalls.Add(1)
+			return NewStatus(true, nil), nil
+		})
+		clock := clocktesting.NewFakeClock(time.Now())
+		h.clock = clock
+
+		ctx, cancel := context.WithCancel(t.Context())
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- h.RunProbes(ctx)
+		}()
+		assert.Eventually(t, clock.HasWaiters, time.Second, time.Microsecond)
+
+		calls := probeCalls.Load()
+		_, err := h.ManualTrigger(t.Context())
+		require.Error(t, err)
+		assert.Equal(t, calls, probeCalls.Load())
+
+		// Once the loop has stopped, probes can be triggered again
+		cancel()
+		require.NoError(t, <-errCh)
+		status, err := h.ManualTrigger(t.Context())
+		require.NoError(t, err)
+		assert.True(t, status.IsHealthy)
+		assert.Equal(t, calls+1, probeCalls.Load())
+		require.NoError(t, h.Close())
+	})
+}
+
+func TestAppHealth_doProbeParentCanceled(t *testing.T) {
+	for name, probeErr := range map[string]error{
+		"probe returns error":     context.Canceled,
+		"probe returns unhealthy": nil,
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(t.Context())
+			t.Cleanup(cancel)
+
+			h := New(config.AppHealthConfig{
+				ProbeTimeout: time.Second,
+				Threshold:    1,
+			}, func(ctx context.Context) (*Status, error) {
+				// Parent is canceled while the probe is in flight
+				cancel()
+				<-ctx.Done()
+				return NewStatus(false, nil), probeErr
+			})
+			h.setResult(t.Context(), NewStatus(true, nil))
+			lastReport := h.lastReport.Load()
+
+			var changes atomic.Int32
+			h.OnHealthChange(func(context.Context, *Status) {
+				changes.Add(1)
+			})
+
+			h.doProbe(ctx)
+			require.NoError(t, h.Close())
+
+			assert.True(t, h.GetStatus().IsHealthy)
+			assert.Equal(t, int32(0), h.failureCount.Load())
+			assert.Equal(t, lastReport, h.lastReport.Load())
+			assert.Equal(t, int32(0), changes.Load())
+		})
+	}
+
+	t.Run("probe timeout is still recorded as a failure", func(t *testing.T) {
+		h := New(config.AppHealthConfig{
+			ProbeTimeout: time.Millisecond,
+			Threshold:    1,
+		}, func(ctx context.Context) (*Status, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+		h.setResult(t.Context(), NewStatus(true, nil))
+
+		h.doProbe(t.Context())
+		require.NoError(t, h.Close())
+
+		assert.False(t, h.GetStatus().IsHealthy)
+	})
+}
+
+func TestAppHealth_SetFallbackProbe(t *testing.T) {
+	var (
+		primaryErr    atomic.Bool
+		primaryCalls  atomic.Int32
+		fallbackCalls atomic.Int32
+	)
+	h := New(config.AppHealthConfig{
+		ProbeTimeout: time.Second,
+		Threshold:    1,
+	}, func(context.Context) (*Status, error) {
+		primaryCalls.Add(1)
+		if primaryErr.Load() {
+			return nil, errors.New("client is broken")
+		}
+		return NewStatus(false, nil), nil
+	})
+	h.SetFallbackProbe(func(context.Context) (*Status, error) {
+		fallbackCalls.Add(1)
+		return NewStatus(true, nil), nil
+	})
+	t.Cleanup(func() { h.Close() })
+
+	// Unhealthy results from the primary don't use the fallback
+	h.doProbe(t.Context())
+	assert.False(t, h.GetStatus().IsHealthy)
+	assert.Equal(t, int32(1), primaryCalls.Load())
+	assert.Equal(t, int32(0), fallbackCalls.Load())
+
+	// Errors from the primary use the fallback's result
+	primaryErr.Store(true)
+	h.doProbe(t.Context())
+	assert.True(t, h.GetStatus().IsHealthy)
+	assert.Equal(t, int32(2), primaryCalls.Load())
+	assert.Equal(t, int32(1), fallbackCalls.Load())
+
+	// Without a fallback, errors are recorded as failures
+	h.SetFallbackProbe(nil)
+	h.doProbe(t.Context())
+	assert.False(t, h.GetStatus().IsHealthy)
+	assert.Equal(t, int32(1), fallbackCalls.Load())
+
+	t.Run("fallback runs after the primary timed out", func(t *testing.T) {
+		h := New(config.AppHealthConfig{
+			ProbeTimeout: 50 * time.Millisecond,
+			Threshold:    1,
+		}, func(ctx context.Context) (*Status, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+		h.SetFallbackProbe(func(ctx context.Context) (*Status, error) {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			return NewStatus(true, nil), nil
+		})
+		t.Cleanup(func() { h.Close() })
+
+		h.doProbe(t.Context())
+		assert.True(t, h.GetStatus().IsHealthy)
+	})
+}
+
+func TestAppHealth_Source(t *testing.T) {
+	var healthy atomic.Bool
+	h := New(config.AppHealthConfig{
+		ProbeInterval: time.Second,
+		ProbeTimeout:  time.Second,
+		Threshold:     1,
+	}, func(context.Context) (*Status, error) {
+		return NewStatus(healthy.Load(), nil), nil
+	})
+	clock := clocktesting.NewFakeClock(time.Now())
+	h.clock = clock
+	t.Cleanup(func() { h.Close() })
+
+	assert.Equal(t, StatusSourceUnknown, h.GetStatus().Source)
+
+	healthy.Store(true)
+	h.doProbe(t.Context())
+	assert.True(t, h.GetStatus().IsHealthy)
+	assert.Equal(t, StatusSourceProbe, h.GetStatus().Source)
+	assert.Equal(t, StatusSourceProbe, h.Snapshot().Source)
+
+	require.NoError(t, h.StartProbes(t.Context()))
+	reported := NewStatus(false, nil)
+	h.ReportHealth(reported)
+	assert.Eventually(t, func() bool {
+		return !h.GetStatus().IsHealthy
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, StatusSourceReport, h.GetStatus().Source)
+	assert.Equal(t, StatusSourceReport, h.Snapshot().Source)
+	// The caller's status is not modified
+	assert.Equal(t, StatusSourceUnknown, reported.Source)
+}
+
+func TestAppHealth_RunProbes(t *testing.T) {
+	t.Run("runs on the caller's goroutine until the context is canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		t.Cleanup(cancel)
+
+		var probeCalls atomic.Int64
+		h := New(config.AppHealthConfig{
+			ProbeInterval: time.Second,
+			Threshold:     1,
+		}, func(context.Context) (*Status, error) {
+			probeCalls.Add(1)
+			return NewStatus(true, nil), nil
+		})
+		clock := clocktesting.NewFakeClock(time.Now())
+		h.clock = clock
+
+		var eg errgroup.Group
+		eg.Go(func() error {
+			return h.RunProbes(ctx)
+		})
+
+		assert.Eventually(t, clock.HasWaiters, time.Second, time.Microsecond)
+		clock.Step(time.Second)
+		assert.Eventually(t, func() bool {
+			return probeCalls.Load() == 1
+		}, time.Second, time.Microsecond)
+
+		cancel()
+		require.NoError(t, eg.Wait())
+		require.NoError(t, h.Close())
+	})
+
+	t.Run("returns when closed", func(t *testing.T) {
+		h := New(config.AppHealthConfig{
+			ProbeInterval: time.Second,
+		}, func(context.Context) (*Status, error) {
+			return NewStatus(true, nil), nil
+		})
+		clock := clocktesting.NewFakeClock(time.Now())
+		h.clock = clock
+
+		errCh := make(chan error)
+		go func() {
+			errCh <- h.RunProbes(t.Context())
+		}()
+		assert.Eventually(t, clock.HasWaiters, time.Second, time.Microsecond)
+
+		require.NoError(t, h.Close())
+		select {
+		case err := <-errCh:
+			require.NoError(t, err)
+		case <-time.After(time.Second):
+			require.Fail(t, "RunProbes didn't return in time")
+		}
+	})
+
+	t.Run("invalid configuration returns error", func(t *testing.T) {
+		h := New(config.AppHealthConfig{}, func(context.Context) (*Status, error) {
+			return NewStatus(true, nil), nil
+		})
+		require.Error(t, h.RunProbes(t.Context()))
+	})
+}
+
+func TestAppHealth_RetryAfter(t *testing.T) {
+	var probeCalls atomic.Int64
+	h := New(config.AppHealthConfig{
+		ProbeInterval: time.Second,
+		ProbeTimeout:  time.Second,
+		Threshold:     1,
+		MaxRetryAfter: 10 * time.Second,
+	}, func(context.Context) (*Status, error) {
+		probeCalls.Add(1)
+		status := NewStatus(false, nil)
+		status.RetryAfter = time.Minute
+		return status, nil
+	})
+	clock := clocktesting.NewFakeClock(time.Now())
+	h.clock = clock
+	h.rand = rand.New(rand.NewPCG(1, 2))
+	t.Cleanup(func() { h.Close() })
+
+	require.NoError(t, h.StartProbes(t.Context()))
+	assert.Eventually(t, clock.HasWaiters, time.Second, time.Microsecond)
+
+	clock.Step(time.Second)
+	assert.Eventually(t, func() bool {
+		return probeCalls.Load() == 1
+	}, time.Second, time.Microsecond)
+
+	// The hint is capped at MaxRetryAfter plus up to 10% of jitter
+	nb := *h.probeNotBefore.Load()
+	assert.GreaterOrEqual(t, nb.Sub(clock.Now()), 10*time.Second)
+	assert.Less(t, nb.Sub(clock.Now()), 11*time.Second)
+
+	// Ticks before the hint expires don't probe
+	for range 9 {
+		clock.Step(time.Second)
+		time.Sleep(5 * time.Millisecond)
+	}
+	assert.Equal(t, int64(1), probeCalls.Load())
+
+	// After the hint expires, probing resumes
+	clock.Step(2 * time.Second)
+	assert.Eventually(t, func() bool {
+		return probeCalls.Load() == 2
+	}, time.Second, time.Microsecond)
+}
+
+func TestAppHealth_Validate(t *testing.T) {
+	probeFn := func(context.Context) (*Status, error) {
+		return NewStatus(true, nil), nil
+	}
+	tests := map[string]struct {
+		config  config.AppHealthConfig
+		probeFn ProbeFunction
+		wantErr bool
+	}{
+		"valid": {
+			config:  config.AppHealthConfig{ProbeInterval: time.Second, ProbeTimeout: time.Millisecond},
+			probeFn: probeFn,
+		},
+		"nil probe function": {
+			config:  config.AppHealthConfig{ProbeInterval: time.Second},
+			wantErr: true,
+		},
+		"zero interval": {
+			config:  config.AppHealthConfig{},
+			probeFn: probeFn,
+			wantErr: true,
+		},
+		"timeout larger than interval": {
+			config:  config.AppHealthConfig{ProbeInterval: time.Second, ProbeTimeout: 2 * time.Second},
+			probeFn: probeFn,
+			wantErr: true,
+		},
+		"maximum threshold": {
+			config:  config.AppHealthConfig{ProbeInterval: time.Second, Threshold: config.AppHealthConfigMaxThreshold},
+			probeFn: probeFn,
+		},
+		"threshold above maximum": {
+			config:  config.AppHealthConfig{ProbeInterval: time.Second, Threshold: config.AppHealthConfigMaxThreshold + 1},
+			probeFn: probeFn,
+			wantErr: true,
+		},
+		"timeout equal to interval": {
+			config:  config.AppHealthConfig{ProbeInterval: time.Second, ProbeTimeout: time.Second},
+			probeFn: probeFn,
+		},
+		"timeout within headroom": {
+			config:  config.AppHealthConfig{ProbeInterval: time.Second, ProbeTimeout: 900 * time.Millisecond, ProbeTimeoutHeadroom: 0.1},
+			probeFn: probeFn,
+		},
+		"timeout just above headroom": {
+			config:  config.AppHealthConfig{ProbeInterval: time.Second, ProbeTimeout: 900*time.Millisecond + 1, ProbeTimeoutHeadroom: 0.1},
+			probeFn: probeFn,
+			wantErr: true,
+		},
+		"timeout equal to interval with headroom": {
+			config:  config.AppHealthConfig{ProbeInterval: time.Second, ProbeTimeout: time.Second, ProbeTimeoutHeadroom: 0.1},
+			probeFn: probeFn,
+			wantErr: true,
+		},
+		"negative headroom": {
+			config:  config.AppHealthConfig{ProbeInterval: time.Second, ProbeTimeoutHeadroom: -0.1},
+			probeFn: probeFn,
+			wantErr: true,
+		},
+		"headroom of the whole interval": {
+			config:  config.AppHealthConfig{ProbeInterval: time.Second, ProbeTimeoutHeadroom: 1},
+			probeFn: probeFn,
+			wantErr: true,
+		},
+		"negative startup burst": {
+			config:  config.AppHealthConfig{ProbeInterval: time.Second, StartupBurst: -1},
+			probeFn: probeFn,
+			wantErr: true,
+		},
+		"negative confirmation probes": {
+			config:  config.AppHealthConfig{ProbeInterval: time.Second, ConfirmationProbes: -1},
+			probeFn: probeFn,
+			wantErr: true,
+		},
+		"max int32 threshold": {
+			config:  config.AppHealthConfig{ProbeInterval: time.Second, Threshold: math.MaxInt32},
+			probeFn: probeFn,
+			wantErr: true,
+		},
+		"negative threshold": {
+			config:  config.AppHealthConfig{ProbeInterval: time.Second, Threshold: -1},
+			probeFn: probeFn,
+			wantErr: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := New(tc.config, tc.probeFn).Validate()
+			if tc.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAppHealth_ReportsOverrideProbes(t *testing.T) {
+	reported := func() *Status {
+		s := NewStatus(true, nil)
+		s.Source = StatusSourceReport
+		return s
+	}
+	probed := func(healthy bool) *Status {
+		s := NewStatus(healthy, nil)
+		s.Source = StatusSourceProbe
+		return s
+	}
+
+	t.Run("by default reports clear probe failures", func(t *testing.T) {
+		h := New(config.AppHealthConfig{Threshold: 1}, nil)
+		h.setResult(t.Context(), probed(false))
+		assert.False(t, h.GetStatus().IsHealthy)
+
+		h.setResult(t.Context(), reported())
+		assert.True(t, h.GetStatus().IsHealthy)
+		assert.NotNil(t, h.LastReported())
+		require.NoError(t, h.Close())
+	})
+
+	t.Run("reports don't clear probe failures when disabled", func(t *testing.T) {
+		h := New(config.AppHealthConfig{
+			Threshold:             1,
+			ReportsOverrideProbes: ptr.Of(false),
+		}, nil)
+		h.setResult(t.Context(), probed(false))
+		assert.False(t, h.GetStatus().IsHealthy)
+
+		r := reported()
+		h.setResult(t.Context(), r)
+		assert.False(t, h.GetStatus().IsHealthy)
+		assert.Same(t, r, h.LastReported())
+		assert.Equal(t, StatusSourceProbe, h.Source())
+
+		// Only a probe can bring the app back to healthy
+		h.setResult(t.Context(), probed(true))
+		assert.True(t, h.GetStatus().IsHealthy)
+		require.NoError(t, h.Close())
+	})
+}
+
+func TestAppHealth_probeInterval(t *testing.T) {
+	tests := map[string]struct {
+		interval    time.Duration
+		minInterval time.Duration
+		want        time.Duration
+	}{
+		"above default minimum":     {interval: time.Second, want: time.Second},
+		"clamped to default":        {interval: time.Millisecond, want: config.AppHealthConfigDefaultMinProbeInterval},
+		"clamped to custom minimum": {interval: time.Second, minInterval: 2 * time.Second, want: 2 * time.Second},
+		"clamping disabled":         {interval: time.Millisecond, minInterval: -1, want: time.Millisecond},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			h := New(config.AppHealthConfig{
+				ProbeInterval:    tc.interval,
+				MinProbeInterval: tc.minInterval,
+			}, nil)
+			assert.Equal(t, tc.want, h.probeInterval())
+			// Calling again (which doesn't log again) returns the same value
+			assert.Equal(t, tc.want, h.probeInterval())
+		})
+	}
+
+	t.Run("probe loop uses the clamped interval", func(t *testing.T) {
+		var probeCalls atomic.Int64
+		h := New(config.AppHealthConfig{
+			ProbeInterval: time.Millisecond,
+			ProbeTimeout:  time.Millisecond,
+			Threshold:     1,
+		}, func(context.Context) (*Status, error) {
+			probeCalls.Add(1)
+			return NewStatus(true, nil), nil
+		})
+		clock := clocktesting.NewFakeClock(time.Now())
+		h.clock = clock
+		t.Cleanup(func() { h.Close() })
+
+		require.NoError(t, h.StartProbes(t.Context()))
+		assert.Eventually(t, clock.HasWaiters, time.Second, time.Microsecond)
+
+		clock.Step(50 * time.Millisecond)
+		time.Sleep(10 * time.Millisecond)
+		assert.Equal(t, int64(0), probeCalls.Load())
+
+		clock.Step(50 * time.Millisecond)
+		assert.Eventually(t, func() bool {
+			return probeCalls.Load() == 1
+		}, time.Second, time.Microsecond)
+	})
+}
+
+func TestAppHealth_SetReasonFormatter(t *testing.T) {
+	h := New(config.AppHealthConfig{Threshold: 2}, nil)
+	h.setResult(t.Context(), NewStatus(false, nil))
+
+	assert.Equal(t, "App health check failed 3 times", *h.GetStatus().Reason)
+
+	h.SetReasonFormatter(func(failureCount, threshold int32) string {
+		return fmt.Sprintf("%d/%d failures, see https://runbooks.example.com/app-health", failureCount, threshold)
+	})
+	assert.Equal(t, "3/2 failures, see https://runbooks.example.com/app-health", *h.GetStatus().Reason)
+
+	// Nil restores the default
+	h.SetReasonFormatter(nil)
+	assert.Equal(t, "App health check failed 3 times", *h.GetStatus().Reason)
+
+	h.setResult(t.Context(), NewStatus(true, nil))
+	assert.Nil(t, h.GetStatus().Reason)
+	require.NoError(t, h.Close())
+}
+
+func TestAppHealth_TimeSinceLastReport(t *testing.T) {
+	h := New(config.AppHealthConfig{Threshold: 1}, nil)
+	clock := clocktesting.NewFakeClock(time.Unix(1700000000, 0))
+	h.clock = clock
+
+	_, ok := h.TimeSinceLastReport()
+	assert.False(t, ok)
+
+	h.setResult(t.Context(), NewStatus(true, nil))
+	clock.Step(5 * time.Second)
+	d, ok := h.TimeSinceLastReport()
+	require.True(t, ok)
+	assert.Equal(t, 5*time.Second, d)
+
+	// Simulate the wall clock jumping backwards, such as after a NTP correction
+	clock.SetTime(time.Unix(1700000000, 0).Add(-time.Minute))
+	d, ok = h.TimeSinceLastReport()
+	require.True(t, ok)
+	assert.Equal(t, time.Duration(0), d)
+
+	// Display time is still the wall-clock time of the report
+	assert.Equal(t, time.Unix(1700000000, 0), h.Snapshot().LastReport)
+
+	t.Run("real clock uses the monotonic reading", func(t *testing.T) {
+		h := New(config.AppHealthConfig{Threshold: 1}, nil)
+		h.setResult(t.Context(), NewStatus(true, nil))
+		lr := h.lastReportAt.Load()
+		require.NotNil(t, lr)
+		// Round(0) strips the monotonic reading, so a difference means it is present
+		assert.NotEqual(t, lr.Round(0), *lr)
+	})
+	require.NoError(t, h.Close())
+}
+
+func TestAppHealth_EnterLenientMode(t *testing.T) {
+	h := New(config.AppHealthConfig{
+		Threshold: 2,
+	}, nil)
+	clock := clocktesting.NewFakeClock(time.Now())
+	h.clock = clock
+
+	h.setResult(t.Context(), NewStatus(true, nil))
+	require.True(t, h.GetStatus().IsHealthy)
+
+	h.EnterLenientMode(10 * time.Second)
+	for range 5 {
+		h.setResult(t.Context(), NewStatus(false, nil))
+	}
+	assert.True(t, h.GetStatus().IsHealthy)
+	assert.Equal(t, int32(0), h.failureCount.Load())
+	lr, ok := h.TimeSinceLastReport()
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), lr)
+
+	// Counting resumes after the window
+	clock.Step(10 * time.Second)
+	h.setResult(t.Context(), NewStatus(false, nil))
+	assert.Equal(t, int32(1), h.failureCount.Load())
+	h.setResult(t.Context(), NewStatus(false, nil))
+	assert.False(t, h.GetStatus().IsHealthy)
+
+	// Successes during the window still reset the count
+	h.EnterLenientMode(time.Second)
+	h.setResult(t.Context(), NewStatus(true, nil))
+	assert.True(t, h.GetStatus().IsHealthy)
+}
+
+func TestAppHealth_HasBeenHealthy(t *testing.T) {
+	h := New(config.AppHealthConfig{
+		Threshold: 1,
+	}, nil)
+	t.Cleanup(func() { h.Close() })
+	assert.False(t, h.HasBeenHealthy())
+
+	h.setResult(t.Context(), NewStatus(false, nil))
+	assert.False(t, h.HasBeenHealthy())
+
+	h.setResult(t.Context(), NewStatus(true, nil))
+	assert.True(t, h.HasBeenHealthy())
+
+	// It's sticky across unhealthy transitions
+	h.setResult(t.Context(), NewStatus(false, nil))
+	assert.False(t, h.IsHealthy())
+	assert.True(t, h.HasBeenHealthy())
+}
+
+func TestAppHealth_IsHealthy(t *testing.T) {
+	h := New(config.AppHealthConfig{
+		Threshold: 2,
+	}, nil)
+	assert.False(t, h.IsHealthy())
+
+	h.setResult(t.Context(), NewStatus(true, nil))
+	assert.True(t, h.IsHealthy())
+	h.setResult(t.Context(), NewStatus(false, nil))
+	assert.True(t, h.IsHealthy())
+	h.setResult(t.Context(), NewStatus(false, nil))
+	assert.False(t, h.IsHealthy())
+}
+
+func TestAppHealth_ProbeSpacing(t *testing.T) {
+	clock := clocktesting.NewFakeClock(time.Now())
+	var (
+		lock   sync.Mutex
+		starts []time.Time
+	)
+	h := New(config.AppHealthConfig{
+		ProbeInterval: time.Second,
+		ProbeTimeout:  time.Second,
+		Threshold:     1,
+	}, func(context.Context) (*Status, error) {
+		lock.Lock()
+		starts = append(starts, clock.Now())
+		lock.Unlock()
+		// Simulate a slow probe
+		clock.Step(300 * time.Millisecond)
+		return NewStatus(true, nil), nil
+	})
+	h.clock = clock
+	t.Cleanup(func() { h.Close() })
+
+	probeCalls := func() int {
+		lock.Lock()
+		defer lock.Unlock()
+		return len(starts)
+	}
+
+	require.NoError(t, h.StartProbes(t.Context()))
+	assert.Eventually(t, clock.HasWaiters, time.Second, time.Microsecond)
+
+	clock.Step(time.Second)
+	assert.Eventually(t, func() bool {
+		return probeCalls() == 1 && clock.HasWaiters()
+	}, time.Second, time.Microsecond)
+
+	// The next probe starts one interval after the previous one started, not after it finished
+	clock.Step(699 * time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, 1, probeCalls())
+	clock.Step(time.Millisecond)
+	assert.Eventually(t, func() bool {
+		return probeCalls() == 2
+	}, time.Second, time.Microsecond)
+
+	lock.Lock()
+	defer lock.Unlock()
+	assert.Equal(t, time.Second, starts[1].Sub(starts[0]))
+}
+
+func TestAppHealth_OverrideInterval(t *testing.T) {
+	clock := clocktesting.NewFakeClock(time.Now())
+	var probeCalls atomic.Int64
+	h := New(config.AppHealthConfig{
+		ProbeInterval: 10 * time.Second,
+		Threshold:     1,
+	}, func(context.Context) (*Status, error) {
+		probeCalls.Add(1)
+		return NewStatus(true, nil), nil
+	})
+	h.clock = clock
+	t.Cleanup(func() { h.Close() })
+
+	require.Error(t, h.OverrideInterval(0, time.Minute))
+	require.Error(t, h.OverrideInterval(time.Second, 0))
+
+	require.NoError(t, h.OverrideInterval(time.Second, 3*time.Second))
+	require.NoError(t, h.StartProbes(t.Context()))
+	assert.Eventually(t, func() bool {
+		return len(h.intervalCh) == 0 && clock.HasWaiters()
+	}, time.Second, time.Microsecond)
+	time.Sleep(10 * time.Millisecond)
+
+	waitForProbe := func(n int64) {
+		t.Helper()
+		assert.Eventually(t, func() bool {
+			return probeCalls.Load() == n && h.Phase() == PhaseWaitingForTick
+		}, time.Second, time.Microsecond)
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// Probes run on the overridden interval until it expires
+	for i := range 3 {
+		clock.Step(time.Second)
+		waitForProbe(int64(i + 1))
+	}
+
+	// Then the configured interval is used again
+	clock.Step(9 * time.Second)
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, int64(3), probeCalls.Load())
+	assert.Nil(t, h.intervalOverride.Load())
+	clock.Step(time.Second)
+	waitForProbe(4)
+
+	// Closing during an override is clean
+	require.NoError(t, h.OverrideInterval(time.Second, time.Hour))
+	require.NoError(t, h.Close())
+}
+
+func TestAppHealth_MaxReportAge(t *testing.T) {
+	clock := clocktesting.NewFakeClock(time.Now())
+	h := New(config.AppHealthConfig{
+		Threshold:    1,
+		MaxReportAge: time.Minute,
+	}, nil)
+	h.clock = clock
+	t.Cleanup(func() { h.Close() })
+
+	probe := NewStatus(true, nil)
+	probe.Source = StatusSourceProbe
+	report := func(healthy bool, reason *string) {
+		status := NewStatus(healthy, reason)
+		status.Source = StatusSourceReport
+		h.setResult(t.Context(), status)
+	}
+	assertUnhealthy := func(reason string) {
+		t.Helper()
+		status := h.GetStatus()
+		assert.False(t, status.IsHealthy)
+		assert.False(t, h.IsHealthy())
+		require.NotNil(t, status.Reason)
+		assert.Equal(t, reason, *status.Reason)
+	}
+
+	// The probe passing isn't enough without a report
+	h.setResult(t.Context(), probe)
+	assertUnhealthy("No health report received from the app")
+
+	report(true, nil)
+	assert.True(t, h.GetStatus().IsHealthy)
+	assert.True(t, h.IsHealthy())
+
+	// Reports don't affect the failure count
+	reason := "db down"
+	report(false, &reason)
+	assert.Equal(t, int32(0), h.failureCount.Load())
+	assertUnhealthy("App reported itself unhealthy: db down")
+
+	report(true, nil)
+	clock.Step(time.Minute + time.Second)
+	assertUnhealthy("No health report received from the app in the last 1m0s")
+
+	// A failing probe makes the app unhealthy even with a recent healthy report
+	report(true, nil)
+	h.setResult(t.Context(), NewStatus(false, nil))
+	assertUnhealthy("App health check failed 1 times")
+}
+
+func TestAppHealth_ReportBeforeStart(t *testing.T) {
+	h := New(config.AppHealthConfig{
+		ProbeInterval: time.Second,
+		Threshold:     1,
+	}, func(context.Context) (*Status, error) {
+		return NewStatus(true, nil), nil
+	})
+	clock := clocktesting.NewFakeClock(time.Now())
+	h.clock = clock
+
+	changes := make(chan bool, 2)
+	h.OnHealthChange(func(_ context.Context, status *Status) {
+		changes <- status.IsHealthy
+	})
+
+	// Reports are applied right away when the probe loop isn't running
+	h.ReportHealth(NewStatus(true, nil))
+	assert.True(t, h.IsHealthy())
+	assert.Equal(t, StatusSourceReport, h.Source())
+	assert.True(t, <-changes)
+
+	h.ReportHealth(NewStatus(false, nil))
+	assert.False(t, h.IsHealthy())
+	assert.False(t, <-changes)
+
+	// Once the loop is running, reports go through it
+	ctx, cancel := context.WithCancel(t.Context())
+	var eg errgroup.Group
+	eg.Go(func() error {
+		return h.RunProbes(ctx)
+	})
+	assert.Eventually(t, clock.HasWaiters, time.Second, time.Microsecond)
+	h.ReportHealth(NewStatus(true, nil))
+	assert.Eventually(t, h.IsHealthy, time.Second, time.Microsecond)
+
+	cancel()
+	require.NoError(t, eg.Wait())
+	require.NoError(t, h.Close())
+
+	// Reports are rejected after closing
+	require.ErrorIs(t, h.ReportHealth(NewStatus(false, nil)), ErrClosed)
+	assert.True(t, h.IsHealthy())
+}
+
+func TestAppHealth_LatencyUnhealthyThreshold(t *testing.T) {
+	clock := clocktesting.NewFakeClock(time.Now())
+	var latency atomic.Int64
+	h := New(config.AppHealthConfig{
+		ProbeTimeout:              time.Second,
+		Threshold:                 1,
+		LatencyUnhealthyThreshold: 500 * time.Millisecond,
+	}, func(context.Context) (*Status, error) {
+		clock.Step(time.Duration(latency.Load()))
+		return NewStatus(true, nil), nil
+	})
+	h.clock = clock
+	t.Cleanup(func() { h.Close() })
+
+	latency.Store(int64(100 * time.Millisecond))
+	h.doProbe(t.Context())
+	assert.True(t, h.IsHealthy())
+
+	latency.Store(int64(600 * time.Millisecond))
+	h.doProbe(t.Context())
+	assert.False(t, h.IsHealthy())
+	events := h.History()
+	require.Len(t, events, 2)
+	last := events[1].To
+	assert.False(t, last.IsHealthy)
+	assert.Equal(t, ReasonCodeSlowResponse, last.Code)
+	require.NotNil(t, last.Reason)
+	assert.Equal(t, "App health probe succeeded but took 600ms, more than the threshold of 500ms", *last.Reason)
+
+	// Exactly at the threshold is still healthy
+	latency.Store(int64(500 * time.Millisecond))
+	h.doProbe(t.Context())
+	assert.True(t, h.IsHealthy())
+}
+
+func TestAppHealth_ReportNilStatus(t *testing.T) {
+	h := New(config.AppHealthConfig{Threshold: 1}, nil)
+	t.Cleanup(func() { h.Close() })
+	h.setResult(t.Context(), NewStatus(true, nil))
+
+	require.Error(t, h.ReportHealth(nil))
+	assert.True(t, h.IsHealthy())
+	assert.Empty(t, h.report)
+}
+
+func TestAppHealth_ReportsCancelProbes(t *testing.T) {
+	clock := clocktesting.NewFakeClock(time.Now())
+	started := make(chan struct{}, 1)
+	h := New(config.AppHealthConfig{
+		ProbeInterval:       time.Minute,
+		ProbeTimeout:        time.Minute,
+		Threshold:           1,
+		ReportsCancelProbes: true,
+	}, func(ctx context.Context) (*Status, error) {
+		started <- struct{}{}
+		// The probe would fail, but it's canceled by the report
+		<-ctx.Done()
+		return NewStatus(false, nil), nil
+	})
+	h.clock = clock
+	t.Cleanup(func() { h.Close() })
+
+	h.ReportHealth(NewStatus(true, nil))
+	require.True(t, h.IsHealthy())
+
+	require.NoError(t, h.StartProbes(t.Context()))
+	assert.Eventually(t, clock.HasWaiters, time.Second, time.Microsecond)
+	clock.Step(time.Minute)
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		require.Fail(t, "probe not started")
+	}
+
+	h.ReportHealth(NewStatus(true, nil))
+	assert.Eventually(t, func() bool {
+		return h.Phase() == PhaseWaitingForTick && len(h.report) == 0
+	}, time.Second, time.Microsecond)
+	assert.True(t, h.IsHealthy())
+	assert.Len(t, h.History(), 1)
+}
+
+func TestAppHealth_SetStatusInterceptor(t *testing.T) {
+	var probeErr error
+	h := New(config.AppHealthConfig{
+		ProbeTimeout: time.Second,
+		Threshold:    1,
+	}, func(context.Context) (*Status, error) {
+		if probeErr != nil {
+			return nil, probeErr
+		}
+		return NewStatus(false, nil), nil
+	})
+	t.Cleanup(func() { h.Close() })
+
+	// A nil interceptor is a no-op
+	h.SetStatusInterceptor(nil)
+	h.setResult(t.Context(), NewStatus(true, nil))
+	h.doProbe(t.Context())
+	assert.False(t, h.IsHealthy())
+
+	var seen []StatusSource
+	h.SetStatusInterceptor(func(status *Status) *Status {
+		seen = append(seen, status.Source)
+		// Force healthy and annotate the reason
+		reason := "forced healthy"
+		return NewStatus(true, &reason)
+	})
+	h.doProbe(t.Context())
+	assert.True(t, h.IsHealthy())
+	assert.Equal(t, StatusSourceProbe, h.Source())
+
+	probeErr = errors.New("boom")
+	h.doProbe(t.Context())
+	assert.True(t, h.IsHealthy())
+
+	h.ReportHealth(NewStatus(false, nil))
+	assert.True(t, h.IsHealthy())
+	assert.Equal(t, []StatusSource{StatusSourceProbe, StatusSourceProbe, StatusSourceReport}, seen)
+	require.NotNil(t, h.LastReported().Reason)
+	assert.Equal(t, "forced healthy", *h.LastReported().Reason)
+
+	// Dropped results are not counted
+	h.SetStatusInterceptor(func(*Status) *Status {
+		return nil
+	})
+	h.ReportHealth(NewStatus(false, nil))
+	probeErr = nil
+	h.doProbe(t.Context())
+	assert.True(t, h.IsHealthy())
+	assert.Equal(t, int32(0), h.failureCount.Load())
+}
+
+func TestAppHealth_FireInitialTransition(t *testing.T) {
+	run := func(t *testing.T, fire *bool) []bool {
+		h := New(config.AppHealthConfig{
+			Threshold:             1,
+			FireInitialTransition: fire,
+		}, nil)
+		var (
+			lock  sync.Mutex
+			calls []bool
+		)
+		h.OnHealthChange(func(_ context.Context, status *Status) {
+			lock.Lock()
+			defer lock.Unlock()
+			calls = append(calls, status.IsHealthy)
+		})
+		b := h.ReadyBarrier()
+
+		h.setResult(t.Context(), NewStatus(true, nil))
+		assert.True(t, b.Ready())
+		h.setResult(t.Context(), NewStatus(false, nil))
+		h.setResult(t.Context(), NewStatus(true, nil))
+		require.NoError(t, h.Close())
+
+		lock.Lock()
+		defer lock.Unlock()
+		return calls
+	}
+
+	t.Run("fires by default", func(t *testing.T) {
+		// Callbacks run in background goroutines, so ordering isn't guaranteed
+		assert.ElementsMatch(t, []bool{true, false, true}, run(t, nil))
+	})
+
+	t.Run("suppressed", func(t *testing.T) {
+		assert.ElementsMatch(t, []bool{false, true}, run(t, ptr.Of(false)))
+	})
+}
+
+func TestAppHealth_CoalesceDuplicateReports(t *testing.T) {
+	reported := func(healthy bool) *Status {
+		s := NewStatus(healthy, nil)
+		s.Source = StatusSourceReport
+		return s
+	}
+	probed := func(healthy bool) *Status {
+		s := NewStatus(healthy, nil)
+		s.Source = StatusSourceProbe
+		return s
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		h := New(config.AppHealthConfig{Threshold: 1}, nil)
+		h.setResult(t.Context(), reported(true))
+		h.setResult(t.Context(), probed(false))
+		assert.False(t, h.GetStatus().IsHealthy)
+
+		// The same report clears the probe failure again
+		h.setResult(t.Context(), reported(true))
+		assert.True(t, h.GetStatus().IsHealthy)
+		require.NoError(t, h.Close())
+	})
+
+	t.Run("duplicate reports only refresh the last report time", func(t *testing.T) {
+		h := New(config.AppHealthConfig{
+			Threshold:                1,
+			CoalesceDuplicateReports: true,
+		}, nil)
+		clock := clocktesting.NewFakeClock(time.Now())
+		h.clock = clock
+
+		h.setResult(t.Context(), reported(true))
+		h.setResult(t.Context(), probed(false))
+		assert.False(t, h.GetStatus().IsHealthy)
+
+		clock.Step(time.Second)
+		h.setResult(t.Context(), reported(true))
+		assert.False(t, h.GetStatus().IsHealthy)
+		assert.Equal(t, StatusSourceProbe, h.Source())
+		lr, ok := h.TimeSinceLastReport()
+		assert.True(t, ok)
+		assert.Equal(t, time.Duration(0), lr)
+
+		// A different report is applied
+		h.setResult(t.Context(), reported(false))
+		h.setResult(t.Context(), reported(true))
+		assert.True(t, h.GetStatus().IsHealthy)
+		require.NoError(t, h.Close())
+	})
+}
+
+func BenchmarkAppHealth_CoalesceDuplicateReports(b *testing.B) {
+	// Long reasons that only differ at the end, like error payloads that include a request ID
+	prefix := strings.Repeat("upstream returned an error: ", 64)
+	reasons := []string{prefix + "request 1", prefix + "request 2"}
+	report := func(reason string) *Status {
+		// Copied so the reasons don't share memory, like reports decoded from requests
+		r := strings.Clone(reason)
+		s := NewStatus(false, &r)
+		s.Source = StatusSourceReport
+		return s
+	}
+
+	for name, next := range map[string]func(i int) string{
+		"duplicate": func(int) string { return reasons[0] },
+		"different": func(i int) string { return reasons[i%2] },
+	} {
+		b.Run(name, func(b *testing.B) {
+			h := New(config.AppHealthConfig{
+				Threshold:                1,
+				CoalesceDuplicateReports: true,
+				HistorySize:              -1,
+			}, nil)
+			b.Cleanup(func() { h.Close() })
+
+			statuses := make([]*Status, 64)
+			for i := range statuses {
+				statuses[i] = report(next(i))
+			}
+			b.ReportAllocs()
+			i := 0
+			for b.Loop() {
+				h.setResult(b.Context(), statuses[i%len(statuses)])
+				i++
+			}
+		})
+	}
+}
+
+func TestSameReport(t *testing.T) {
+	a, b := "a", "b"
+	assert.True(t, sameReport(NewStatus(true, nil), NewStatus(true, nil)))
+	assert.False(t, sameReport(NewStatus(true, nil), NewStatus(false, nil)))
+	assert.True(t, sameReport(NewStatus(false, &a), NewStatus(false, ptr.Of("a"))))
+	assert.False(t, sameReport(NewStatus(false, &a), NewStatus(false, &b)))
+	assert.False(t, sameReport(NewStatus(false, &a), NewStatus(false, nil)))
+}
+
+func TestAppHealth_TraceDecisions(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(os.Stdout) })
+
+	h := New(config.AppHealthConfig{
+		Threshold:      2,
+		TraceDecisions: true,
+	}, nil)
+	h.setResult(t.Context(), NewStatus(true, nil))
+	h.setResult(t.Context(), NewStatus(false, nil))
+	h.setResult(t.Context(), NewStatus(false, nil))
+	require.NoError(t, h.Close())
+
+	out := buf.String()
+	assert.Equal(t, 3, strings.Count(out, "App health decision"))
+	assert.Contains(t, out, "transition: healthy")
+	assert.Contains(t, out, "counted: below threshold")
+	assert.Contains(t, out, "transition: unhealthy")
+
+	t.Run("disabled", func(t *testing.T) {
+		buf.Reset()
+		h := New(config.AppHealthConfig{
+			Threshold: 2,
+		}, nil)
+		h.setResult(t.Context(), NewStatus(true, nil))
+		require.NoError(t, h.Close())
+		assert.NotContains(t, buf.String(), "App health decision")
+	})
+}
+
+type syncBuffer struct {
+	lock sync.Mutex
+	buf  bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buf.String()
+}
+
+func TestAppHealth_AliveLogInterval(t *testing.T) {
+	var buf syncBuffer
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(os.Stdout) })
+
+	clock := clocktesting.NewFakeClock(time.Now())
+	h := New(config.AppHealthConfig{
+		ProbeInterval:    time.Hour,
+		ProbeTimeout:     time.Second,
+		Threshold:        1,
+		AliveLogInterval: time.Minute,
+	}, func(context.Context) (*Status, error) {
+		return NewStatus(true, nil), nil
+	})
+	h.clock = clock
+	t.Cleanup(func() { h.Close() })
+
+	require.NoError(t, h.StartProbes(t.Context()))
+	assert.Eventually(t, clock.HasWaiters, time.Second, time.Microsecond)
+	clock.Step(time.Minute)
+	assert.Eventually(t, func() bool {
+		return strings.Contains(buf.String(), "App health probe loop alive, no probe yet, status unhealthy")
+	}, time.Second, time.Millisecond)
+
+	h.doProbe(t.Context())
+	clock.Step(time.Minute)
+	assert.Eventually(t, func() bool {
+		return strings.Contains(buf.String(), "App health probe loop alive, last probe 1m0s ago, status healthy")
+	}, time.Second, time.Millisecond)
+
+	t.Run("disabled", func(t *testing.T) {
+		h := New(config.AppHealthConfig{
+			ProbeInterval: time.Hour,
+			ProbeTimeout:  time.Second,
+			Threshold:     1,
+		}, func(context.Context) (*Status, error) {
+			return NewStatus(true, nil), nil
+		})
+		clock := clocktesting.NewFakeClock(time.Now())
+		h.clock = clock
+		t.Cleanup(func() { h.Close() })
+
+		require.NoError(t, h.StartProbes(t.Context()))
+		assert.Eventually(t, clock.HasWaiters, time.Second, time.Microsecond)
+		clock.Step(time.Minute)
+		time.Sleep(10 * time.Millisecond)
+		assert.Equal(t, 2, strings.Count(buf.String(), "App health probe loop alive"))
+	})
+}
+
+func TestAppHealth_StartupQuietPeriod(t *testing.T) {
+	var buf syncBuffer
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(os.Stdout) })
+
+	clock := clocktesting.NewFakeClock(time.Now())
+	h := New(config.AppHealthConfig{
+		ProbeInterval:      time.Hour,
+		ProbeTimeout:       time.Second,
+		Threshold:          1,
+		StartupQuietPeriod: time.Minute,
+	}, func(context.Context) (*Status, error) {
+		return nil, errors.New("connection refused")
+	})
+	h.clock = clock
+	t.Cleanup(func() { h.Close() })
+
+	require.NoError(t, h.StartProbes(t.Context()))
+	assert.Eventually(t, func() bool {
+		return h.quietUntil.Load() != nil
+	}, time.Second, time.Millisecond)
+
+	// Failures during the quiet period aren't logged as warnings or errors
+	h.setResult(t.Context(), NewStatus(true, nil))
+	h.doProbe(t.Context())
+	assert.False(t, h.IsHealthy())
+	assert.NotContains(t, buf.String(), "App entered un-healthy status")
+	assert.NotContains(t, buf.String(), "App health probe could not complete with error")
+
+	// Full logging resumes after the quiet period
+	clock.Step(time.Minute)
+	h.setResult(t.Context(), NewStatus(true, nil))
+	h.doProbe(t.Context())
+	assert.False(t, h.IsHealthy())
+	assert.Contains(t, buf.String(), "App entered un-healthy status")
+	assert.Contains(t, buf.String(), "App health probe could not complete with error: connection refused")
+}
+
+func TestAppHealth_IncludeSuccessReason(t *testing.T) {
+	run := func(t *testing.T, include bool) (*AppHealth, *Status) {
+		h := New(config.AppHealthConfig{
+			ProbeTimeout:         time.Second,
+			Threshold:            1,
+			IncludeSuccessReason: include,
+		}, func(context.Context) (*Status, error) {
+			reason := "HTTP 200 in 1ms"
+			return NewStatus(true, &reason), nil
+		})
+		t.Cleanup(func() { h.Close() })
+
+		status, err := h.ManualTrigger(t.Context())
+		require.NoError(t, err)
+		return h, status
+	}
+
+	t.Run("not included by default", func(t *testing.T) {
+		h, status := run(t, false)
+		assert.True(t, status.IsHealthy)
+		assert.Nil(t, status.Reason)
+
+		// The history keeps the probe's reason regardless
+		history := h.History()
+		require.Len(t, history, 1)
+		require.NotNil(t, history[0].To.Reason)
+		assert.Equal(t, "HTTP 200 in 1ms", *history[0].To.Reason)
+	})
+
+	t.Run("included", func(t *testing.T) {
+		h, status := run(t, true)
+		assert.True(t, status.IsHealthy)
+		require.NotNil(t, status.Reason)
+		assert.Equal(t, "HTTP 200 in 1ms", *status.Reason)
+
+		// A healthy result without reason clears it
+		h.setResult(t.Context(), NewStatus(true, nil))
+		assert.Nil(t, h.GetStatus().Reason)
+	})
+}
+
+func TestAppHealth_NotYetProbed(t *testing.T) {
+	h := New(config.AppHealthConfig{Threshold: 2}, nil)
+
+	status := h.GetStatus()
+	assert.False(t, status.IsHealthy)
+	assert.Equal(t, ReasonCodeNotYetProbed, status.Code)
+	require.NotNil(t, status.Reason)
+	assert.Equal(t, "Health not yet determined", *status.Reason)
+
+	// Failures after startup report the usual reason
+	h.setResult(t.Context(), NewStatus(false, nil))
+	status = h.GetStatus()
+	assert.False(t, status.IsHealthy)
+	assert.Empty(t, status.Code)
+	assert.Equal(t, "App health check failed 3 times", *status.Reason)
+
+	t.Run("initial transition is from the startup status", func(t *testing.T) {
+		h := New(config.AppHealthConfig{Threshold: 2}, nil)
+		sink := &MemoryAuditSink{}
+		h.SetAuditSink(sink)
+		h.setResult(t.Context(), NewStatus(true, nil))
+		require.NoError(t, h.Close())
+
+		events := sink.Events()
+		require.Len(t, events, 1)
+		assert.Equal(t, ReasonCodeNotYetProbed, events[0].From.Code)
+		assert.True(t, events[0].To.IsHealthy)
+	})
+	require.NoError(t, h.Close())
+}
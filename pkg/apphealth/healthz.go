@@ -0,0 +1,87 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// HealthzHandler returns a HTTP handler that reports the last recorded result of each probe, in the format used by Kubernetes' healthz endpoints.
+// The response status is 200 when all required probes are healthy, and 503 otherwise.
+// The per-probe breakdown is included when the "verbose" query string parameter is set.
+// Probes that haven't run yet are reported as failed, and so is an empty set if SetNoProbesStatus is NoProbesUnhealthy.
+func (s *ProbeSet) HealthzHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.lock.RLock()
+		names := make([]string, 0, len(s.probes))
+		for name := range s.probes {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+
+		var (
+			b      strings.Builder
+			failed bool
+		)
+		for _, name := range names {
+			p := s.probes[name]
+			switch {
+			case p.status == nil:
+				b.WriteString("[-]" + name + " failed: not yet probed")
+			case !p.status.IsHealthy:
+				b.WriteString("[-]" + name + " failed")
+				if p.status.Reason != nil {
+					b.WriteString(": " + *p.status.Reason)
+				}
+			default:
+				b.WriteString("[+]" + name + " ok")
+			}
+			if !p.required {
+				b.WriteString(" (optional)")
+			}
+			b.WriteByte('\n')
+			if p.required && (p.status == nil || !p.status.IsHealthy) {
+				failed = true
+			}
+		}
+		// Matches the result of Probe when no probes are registered
+		if len(names) == 0 && s.noProbes == NoProbesUnhealthy {
+			b.WriteString("[-]no probes registered\n")
+			failed = true
+		}
+		s.lock.RUnlock()
+
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+
+		_, verbose := r.URL.Query()["verbose"]
+		if !verbose {
+			b.Reset()
+		}
+		if failed {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(b.String() + "healthz check failed\n"))
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if verbose {
+			w.Write([]byte(b.String() + "healthz check passed\n"))
+			return
+		}
+		w.Write([]byte("ok"))
+	})
+}
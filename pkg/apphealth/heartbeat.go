@@ -0,0 +1,68 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"time"
+)
+
+// OnHealthyHeartbeat sets a callback that is invoked every interval while the app is healthy, independently of the probe interval.
+// This allows feeding external watchdogs that expect a periodic signal rather than transitions.
+// The callback is invoked synchronously on the heartbeat goroutine, so it should return quickly; its context is canceled when the heartbeat stops.
+// Calling it again replaces the previous heartbeat; passing a nil callback or a non-positive interval disables it.
+func (h *AppHealth) OnHealthyHeartbeat(interval time.Duration, cb func(ctx context.Context)) {
+	h.heartbeatLock.Lock()
+	defer h.heartbeatLock.Unlock()
+
+	if h.heartbeatStop != nil {
+		close(h.heartbeatStop)
+		h.heartbeatStop = nil
+	}
+	if interval <= 0 || cb == nil || h.closed.Load() {
+		return
+	}
+
+	stop := make(chan struct{})
+	h.heartbeatStop = stop
+	ticker := h.clock.NewTicker(interval)
+
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+		defer ticker.Stop()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-h.closeCh:
+				return
+			case <-ticker.C():
+				// The tick may race with the heartbeat being replaced
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				if h.IsHealthy() && !h.closed.Load() {
+					cb(ctx)
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	"github.com/dapr/dapr/pkg/config"
+)
+
+func TestAppHealth_OnHealthyHeartbeat(t *testing.T) {
+	h := New(config.AppHealthConfig{
+		Threshold: 1,
+	}, nil)
+	clock := clocktesting.NewFakeClock(time.Now())
+	h.clock = clock
+
+	var beats atomic.Int32
+	h.OnHealthyHeartbeat(time.Second, func(context.Context) {
+		beats.Add(1)
+	})
+	assert.True(t, clock.HasWaiters())
+
+	// Unhealthy at start: no heartbeat
+	clock.Step(time.Second)
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, int32(0), beats.Load())
+
+	h.setResult(t.Context(), NewStatus(true, nil))
+	clock.Step(time.Second)
+	assert.Eventually(t, func() bool {
+		return beats.Load() == 1
+	}, time.Second, time.Microsecond)
+	clock.Step(time.Second)
+	assert.Eventually(t, func() bool {
+		return beats.Load() == 2
+	}, time.Second, time.Microsecond)
+
+	h.setResult(t.Context(), NewStatus(false, nil))
+	clock.Step(time.Second)
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, int32(2), beats.Load())
+
+	// No heartbeats after Close, even if healthy
+	h.setResult(t.Context(), NewStatus(true, nil))
+	h.Close()
+	clock.Step(time.Second)
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, int32(2), beats.Load())
+}
+
+func TestAppHealth_OnHealthyHeartbeatReplace(t *testing.T) {
+	h := New(config.AppHealthConfig{
+		Threshold: 1,
+	}, nil)
+	clock := clocktesting.NewFakeClock(time.Now())
+	h.clock = clock
+	t.Cleanup(func() { h.Close() })
+	h.setResult(t.Context(), NewStatus(true, nil))
+
+	var first, second atomic.Int32
+	h.OnHealthyHeartbeat(time.Second, func(context.Context) {
+		first.Add(1)
+	})
+	h.OnHealthyHeartbeat(2*time.Second, func(context.Context) {
+		second.Add(1)
+	})
+
+	clock.Step(2 * time.Second)
+	assert.Eventually(t, func() bool {
+		return second.Load() == 1
+	}, time.Second, time.Microsecond)
+	assert.Equal(t, int32(0), first.Load())
+
+	h.OnHealthyHeartbeat(0, nil)
+	clock.Step(2 * time.Second)
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, int32(1), second.Load())
+}
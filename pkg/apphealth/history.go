@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"sync"
+)
+
+// watchBuffer is the number of live events buffered for each watcher, in addition to the replayed ones.
+const watchBuffer = 16
+
+// transitionHistory is a ring buffer of the most recent transitions, which are also delivered to watchers.
+type transitionHistory struct {
+	lock     sync.Mutex
+	buf      []TransitionEvent
+	size     int
+	next     int
+	watchers map[chan TransitionEvent]struct{}
+}
+
+func newTransitionHistory(size int) *transitionHistory {
+	return &transitionHistory{
+		size:     size,
+		watchers: make(map[chan TransitionEvent]struct{}),
+	}
+}
+
+func (t *transitionHistory) record(event TransitionEvent) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if len(t.buf) < t.size {
+		t.buf = append(t.buf, event)
+	} else if t.size > 0 {
+		t.buf[t.next] = event
+		t.next = (t.next + 1) % t.size
+	}
+
+	for ch := range t.watchers {
+		select {
+		case ch <- event:
+		default:
+			// Don't let a slow watcher block transitions
+			log.Warn("Dropping app health transition for a slow watcher")
+		}
+	}
+}
+
+// events returns up to n of the most recent events, from the oldest to the most recent.
+// Must be invoked with the lock held.
+func (t *transitionHistory) events(n int) []TransitionEvent {
+	n = min(n, len(t.buf))
+	res := make([]TransitionEvent, 0, n)
+	for i := len(t.buf) - n; i < len(t.buf); i++ {
+		res = append(res, t.buf[(t.next+i)%len(t.buf)])
+	}
+	return res
+}
+
+// History returns the most recent health transitions, from the oldest to the most recent.
+// The number of transitions that are kept is set by HistorySize.
+func (h *AppHealth) History() []TransitionEvent {
+	h.history.lock.Lock()
+	defer h.history.lock.Unlock()
+	return h.history.events(len(h.history.buf))
+}
+
+// Watch returns a channel that receives up to replay of the most recent transitions, followed by new transitions as they happen.
+// The channel is closed when ctx is canceled or the object is closed.
+// Transitions are dropped for watchers that don't keep up, so they never delay the probe loop.
+func (h *AppHealth) Watch(ctx context.Context, replay int) <-chan TransitionEvent {
+	h.history.lock.Lock()
+	events := h.history.events(max(replay, 0))
+	ch := make(chan TransitionEvent, len(events)+watchBuffer)
+	for _, e := range events {
+		ch <- e
+	}
+	if h.closed.Load() {
+		h.history.lock.Unlock()
+		close(ch)
+		return ch
+	}
+	h.history.watchers[ch] = struct{}{}
+	h.history.lock.Unlock()
+
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+		select {
+		case <-ctx.Done():
+		case <-h.closeCh:
+		}
+		h.history.lock.Lock()
+		delete(h.history.watchers, ch)
+		close(ch)
+		h.history.lock.Unlock()
+	}()
+
+	return ch
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/dapr/pkg/config"
+)
+
+func flap(t *testing.T, h *AppHealth, n int) {
+	t.Helper()
+	for i := range n {
+		h.setResult(t.Context(), NewStatus(i%2 == 0, nil))
+	}
+}
+
+func TestAppHealth_History(t *testing.T) {
+	t.Run("keeps the most recent transitions", func(t *testing.T) {
+		h := New(config.AppHealthConfig{
+			Threshold:   1,
+			HistorySize: 3,
+		}, nil)
+		t.Cleanup(func() { h.Close() })
+
+		assert.Empty(t, h.History())
+		flap(t, h, 5)
+		history := h.History()
+		require.Len(t, history, 3)
+		// healthy, unhealthy, healthy, unhealthy, healthy: the last 3 are kept
+		assert.True(t, history[0].To.IsHealthy)
+		assert.False(t, history[1].To.IsHealthy)
+		assert.True(t, history[2].To.IsHealthy)
+	})
+
+	t.Run("defaults and disabling", func(t *testing.T) {
+		h := New(config.AppHealthConfig{Threshold: 1}, nil)
+		flap(t, h, 20)
+		assert.Len(t, h.History(), config.AppHealthConfigDefaultHistorySize)
+		require.NoError(t, h.Close())
+
+		h = New(config.AppHealthConfig{Threshold: 1, HistorySize: -1}, nil)
+		flap(t, h, 2)
+		assert.Empty(t, h.History())
+		require.NoError(t, h.Close())
+	})
+}
+
+func TestAppHealth_Watch(t *testing.T) {
+	receive := func(t *testing.T, ch <-chan TransitionEvent) TransitionEvent {
+		t.Helper()
+		select {
+		case e, ok := <-ch:
+			require.True(t, ok, "channel closed")
+			return e
+		case <-time.After(time.Second):
+			require.Fail(t, "no event received")
+			return TransitionEvent{}
+		}
+	}
+
+	t.Run("replays history then delivers live transitions", func(t *testing.T) {
+		h := New(config.AppHealthConfig{Threshold: 1}, nil)
+		t.Cleanup(func() { h.Close() })
+		flap(t, h, 3)
+
+		ctx, cancel := context.WithCancel(t.Context())
+		ch := h.Watch(ctx, 2)
+		assert.False(t, receive(t, ch).To.IsHealthy)
+		assert.True(t, receive(t, ch).To.IsHealthy)
+
+		h.setResult(t.Context(), NewStatus(false, nil))
+		assert.False(t, receive(t, ch).To.IsHealthy)
+
+		// The channel is closed when the watcher goes away
+		cancel()
+		assert.Eventually(t, func() bool {
+			_, ok := <-ch
+			return !ok
+		}, time.Second, time.Millisecond)
+		h.history.lock.Lock()
+		assert.Empty(t, h.history.watchers)
+		h.history.lock.Unlock()
+	})
+
+	t.Run("slow watchers don't block transitions", func(t *testing.T) {
+		h := New(config.AppHealthConfig{Threshold: 1}, nil)
+		t.Cleanup(func() { h.Close() })
+		ch := h.Watch(t.Context(), 0)
+
+		flap(t, h, watchBuffer*2)
+		assert.Len(t, ch, watchBuffer)
+	})
+
+	t.Run("closed on Close", func(t *testing.T) {
+		h := New(config.AppHealthConfig{Threshold: 1}, nil)
+		ch := h.Watch(t.Context(), 10)
+		require.NoError(t, h.Close())
+		_, ok := <-ch
+		assert.False(t, ok)
+
+		// Watching after Close returns a closed channel
+		_, ok = <-h.Watch(t.Context(), 10)
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"time"
+)
+
+// HostingConsumer is implemented by subsystems that host work on behalf of the app, such as actors, and must stop hosting it while the app is unhealthy.
+//
+// The contract is:
+//   - AppHealthChanged is usually invoked once per transition, but with MaxCallbacksPerSecond transitions can be coalesced, and with DropCallbacksWhenSaturated they can be dropped; implementations must tolerate repeated or skipped values of Healthy.
+//   - When Healthy is false, the consumer stops accepting new work for the app; for actors, this means unregistering the hosted actor types so placement rebalances them away.
+//   - When Healthy is true, the consumer starts hosting again; for actors, this means registering the hosted actor types again so placement includes the host.
+//   - The change carries everything needed to act on it, so implementations must not probe the app again.
+//   - Calls can be concurrent with a later transition; implementations use TransitionID to discard changes older than the last one they applied.
+type HostingConsumer interface {
+	AppHealthChanged(ctx context.Context, change HealthChange)
+}
+
+// HealthChange describes a transition of the app's health, as delivered to a HostingConsumer.
+type HealthChange struct {
+	// Healthy is the health of the app after the transition.
+	Healthy bool
+	// Reason is the reason of the new status; it's empty if the status doesn't have one.
+	Reason string
+	// Code identifies the reason in a machine-readable way, if set.
+	// For example, ReasonCodeShuttingDown lets consumers drain rather than fail over.
+	Code ReasonCode
+	// Source is where the result that caused the transition came from.
+	Source StatusSource
+	// At is the time of the result that caused the transition.
+	At time.Time
+	// TransitionID identifies the transition; it is zero if the status wasn't delivered as part of a transition.
+	TransitionID uint64
+}
+
+// NewHealthChange returns the HealthChange for the status and context passed to a ChangeCallback.
+func NewHealthChange(ctx context.Context, status *Status) HealthChange {
+	change := HealthChange{
+		Healthy: status.IsHealthy,
+		Code:    status.Code,
+		Source:  status.Source,
+		At:      time.Unix(status.TimeUnix, 0),
+	}
+	if status.Reason != nil {
+		change.Reason = *status.Reason
+	}
+	change.TransitionID, _ = TransitionIDFromContext(ctx)
+	return change
+}
+
+// HostingCallback returns a ChangeCallback that delivers transitions to c, for use with OnHealthChange.
+func HostingCallback(c HostingConsumer) ChangeCallback {
+	return func(ctx context.Context, status *Status) {
+		c.AppHealthChanged(ctx, NewHealthChange(ctx, status))
+	}
+}
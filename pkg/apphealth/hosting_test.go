@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/dapr/pkg/config"
+)
+
+type recordingHostingConsumer struct {
+	lock    sync.Mutex
+	changes []HealthChange
+}
+
+func (r *recordingHostingConsumer) AppHealthChanged(_ context.Context, change HealthChange) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.changes = append(r.changes, change)
+}
+
+func (r *recordingHostingConsumer) get() []HealthChange {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]HealthChange(nil), r.changes...)
+}
+
+func TestHostingCallback(t *testing.T) {
+	h := New(config.AppHealthConfig{
+		Threshold: 1,
+	}, nil)
+	t.Cleanup(func() { h.Close() })
+
+	consumer := &recordingHostingConsumer{}
+	h.OnHealthChange(HostingCallback(consumer))
+
+	h.ReportHealth(NewStatus(true, nil))
+	require.Eventually(t, func() bool {
+		return len(consumer.get()) == 1
+	}, time.Second, time.Millisecond)
+
+	reason := "database unavailable"
+	h.ReportHealth(NewStatus(false, &reason))
+	require.Eventually(t, func() bool {
+		return len(consumer.get()) == 2
+	}, time.Second, time.Millisecond)
+
+	changes := consumer.get()
+	// Callbacks run concurrently, so they may be delivered out of order
+	if changes[0].TransitionID > changes[1].TransitionID {
+		changes[0], changes[1] = changes[1], changes[0]
+	}
+	assert.True(t, changes[0].Healthy)
+	assert.Empty(t, changes[0].Reason)
+	assert.Equal(t, uint64(1), changes[0].TransitionID)
+
+	assert.False(t, changes[1].Healthy)
+	assert.Equal(t, reason, changes[1].Reason)
+	assert.Equal(t, StatusSourceReport, changes[1].Source)
+	assert.Equal(t, uint64(2), changes[1].TransitionID)
+	assert.WithinDuration(t, time.Now(), changes[1].At, time.Minute)
+}
+
+func TestNewHealthChange(t *testing.T) {
+	status := NewStatus(false, nil)
+	status.Code = ReasonCodeShuttingDown
+
+	change := NewHealthChange(t.Context(), status)
+	assert.False(t, change.Healthy)
+	assert.Empty(t, change.Reason)
+	assert.Equal(t, ReasonCodeShuttingDown, change.Code)
+	assert.Zero(t, change.TransitionID)
+	assert.Equal(t, status.TimeUnix, change.At.Unix())
+}
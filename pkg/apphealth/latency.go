@@ -0,0 +1,74 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+// latencySamples is a ring buffer of the most recent probe latencies.
+type latencySamples struct {
+	lock sync.Mutex
+	buf  []time.Duration
+	n    int
+	next int
+}
+
+func newLatencySamples(size int) *latencySamples {
+	return &latencySamples{
+		buf: make([]time.Duration, size),
+	}
+}
+
+func (s *latencySamples) record(d time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.buf[s.next] = d
+	s.next = (s.next + 1) % len(s.buf)
+	if s.n < len(s.buf) {
+		s.n++
+	}
+}
+
+// sorted returns a sorted copy of the samples.
+// The buffer is filled from the start, so the first n entries are the samples regardless of the order they were recorded.
+func (s *latencySamples) sorted() []time.Duration {
+	s.lock.Lock()
+	res := slices.Clone(s.buf[:s.n])
+	s.lock.Unlock()
+	slices.Sort(res)
+	return res
+}
+
+// percentile returns the value at percentile p, in the range (0, 1], using the nearest-rank method.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p * float64(len(sorted))))
+	return sorted[min(max(rank, 1), len(sorted))-1]
+}
+
+// LatencyPercentiles returns the 50th, 95th, and 99th percentiles of the latencies of the most recent probes.
+// It returns zeros if LatencySampleSize is zero or no probe has run yet.
+func (h *AppHealth) LatencyPercentiles() (p50, p95, p99 time.Duration) {
+	if h.latencies == nil {
+		return 0, 0, 0
+	}
+	sorted := h.latencies.sorted()
+	return percentile(sorted, 0.5), percentile(sorted, 0.95), percentile(sorted, 0.99)
+}
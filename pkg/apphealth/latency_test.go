@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	"github.com/dapr/dapr/pkg/config"
+)
+
+func TestAppHealth_LatencyPercentiles(t *testing.T) {
+	newHealth := func(t *testing.T, size int) (*AppHealth, *time.Duration) {
+		clock := clocktesting.NewFakeClock(time.Now())
+		var latency time.Duration
+		h := New(config.AppHealthConfig{
+			ProbeTimeout:      time.Minute,
+			Threshold:         1,
+			LatencySampleSize: size,
+		}, func(context.Context) (*Status, error) {
+			clock.Step(latency)
+			return NewStatus(true, nil), nil
+		})
+		h.clock = clock
+		t.Cleanup(func() { h.Close() })
+		return h, &latency
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		h, latency := newHealth(t, 0)
+		*latency = time.Second
+		h.doProbe(t.Context())
+		assert.Nil(t, h.latencies)
+		p50, p95, p99 := h.LatencyPercentiles()
+		assert.Zero(t, p50)
+		assert.Zero(t, p95)
+		assert.Zero(t, p99)
+	})
+
+	t.Run("no probes yet", func(t *testing.T) {
+		h, _ := newHealth(t, 10)
+		p50, p95, p99 := h.LatencyPercentiles()
+		assert.Zero(t, p50)
+		assert.Zero(t, p95)
+		assert.Zero(t, p99)
+	})
+
+	t.Run("percentiles", func(t *testing.T) {
+		h, latency := newHealth(t, 100)
+		// Record latencies from 100ms down to 1ms, so the order doesn't match the sorted one
+		for i := 100; i > 0; i-- {
+			*latency = time.Duration(i) * time.Millisecond
+			h.doProbe(t.Context())
+		}
+		p50, p95, p99 := h.LatencyPercentiles()
+		assert.Equal(t, 50*time.Millisecond, p50)
+		assert.Equal(t, 95*time.Millisecond, p95)
+		assert.Equal(t, 99*time.Millisecond, p99)
+	})
+
+	t.Run("only the most recent probes are kept", func(t *testing.T) {
+		h, latency := newHealth(t, 4)
+		*latency = time.Hour
+		for range 4 {
+			h.doProbe(t.Context())
+		}
+		*latency = time.Millisecond
+		for range 4 {
+			h.doProbe(t.Context())
+		}
+		p50, p95, p99 := h.LatencyPercentiles()
+		assert.Equal(t, time.Millisecond, p50)
+		assert.Equal(t, time.Millisecond, p95)
+		assert.Equal(t, time.Millisecond, p99)
+	})
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"time"
+
+	"github.com/dapr/dapr/pkg/config"
+)
+
+// checkMaintenance notices maintenance windows starting and ending, like setResult does, unless the object is closed or shutting down.
+func (h *AppHealth) checkMaintenance(ctx context.Context) {
+	if len(h.config.MaintenanceSchedule) == 0 {
+		return
+	}
+
+	h.resultLock.RLock()
+	defer h.resultLock.RUnlock()
+	if h.closed.Load() || h.draining.Load() {
+		return
+	}
+	h.updateMaintenance(ctx, h.clock.Now())
+}
+
+// updateMaintenance records whether now is within one of the windows of the MaintenanceSchedule.
+// The app is reported as healthy during the windows, so if it's unhealthy, a transition to healthy is delivered when the window starts, and a transition back to unhealthy when it ends.
+// It must be called with resultLock held.
+func (h *AppHealth) updateMaintenance(ctx context.Context, now time.Time) {
+	if len(h.config.MaintenanceSchedule) == 0 {
+		return
+	}
+
+	active := inMaintenanceWindow(h.config.MaintenanceSchedule, now)
+	if h.maintenance.Swap(active) == active {
+		return
+	}
+	if active {
+		log.Info("App health maintenance window started; the app is reported as healthy until it ends")
+	} else {
+		log.Info("App health maintenance window ended")
+	}
+
+	// Before the first result, no transition is delivered, as usual
+	fc := h.failureCount.Load()
+	if !h.determined.Load() || fc < h.config.Threshold {
+		return
+	}
+
+	event := TransitionEvent{
+		ID:           h.transitionSeq.Add(1),
+		At:           now,
+		FailureCount: fc,
+	}
+	if active {
+		event.From = h.statusFor(fc, h.Source())
+		event.To = h.maintenanceStatus()
+		log.Infof("App entered healthy status (transition %d): maintenance window started", event.ID)
+	} else {
+		event.From = h.maintenanceStatus()
+		event.To = h.statusFor(fc, h.Source())
+		log.Warnf("App entered un-healthy status (transition %d): %s", event.ID, *event.To.Reason)
+	}
+	notified := h.transition(ctx, event)
+	h.traceDecision(event.To, fc, fc, notified, "transition: maintenance window")
+}
+
+// pinnedByMaintenance returns true if the app is reported as healthy because a maintenance window is active.
+// Shutting down takes precedence.
+func (h *AppHealth) pinnedByMaintenance() bool {
+	return h.maintenance.Load() && !h.draining.Load()
+}
+
+// maintenanceStatus returns the status reported while a maintenance window is active.
+func (h *AppHealth) maintenanceStatus() *Status {
+	reason := "App health maintenance window is active"
+	status := NewStatus(true, &reason)
+	status.Code = ReasonCodeMaintenance
+	status.Source = h.Source()
+	return status
+}
+
+// inMaintenanceWindow returns true if now is within one of the daily windows.
+func inMaintenanceWindow(windows []config.AppHealthMaintenanceWindow, now time.Time) bool {
+	now = now.UTC()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	sinceMidnight := now.Sub(midnight)
+	for _, w := range windows {
+		// Windows that started the day before and end after midnight are matched by wrapping around
+		elapsed := sinceMidnight - w.Start
+		if elapsed < 0 {
+			elapsed += 24 * time.Hour
+		}
+		if elapsed < w.Duration {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	"github.com/dapr/dapr/pkg/config"
+)
+
+func TestInMaintenanceWindow(t *testing.T) {
+	day := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	windows := []config.AppHealthMaintenanceWindow{
+		{Start: 2 * time.Hour, Duration: time.Hour},
+		// Crosses midnight
+		{Start: 23 * time.Hour, Duration: 2 * time.Hour},
+	}
+
+	tests := map[string]struct {
+		at   time.Time
+		want bool
+	}{
+		"before window":         {day.Add(time.Hour + 59*time.Minute), false},
+		"window start":          {day.Add(2 * time.Hour), true},
+		"inside window":         {day.Add(2*time.Hour + 30*time.Minute), true},
+		"window end":            {day.Add(3 * time.Hour), false},
+		"before midnight":       {day.Add(23*time.Hour + 30*time.Minute), true},
+		"after midnight":        {day.Add(30 * time.Minute), true},
+		"end after midnight":    {day.Add(time.Hour), false},
+		"other time zone":       {day.Add(2 * time.Hour).In(time.FixedZone("UTC+5", 5*60*60)), true},
+		"outside of any window": {day.Add(12 * time.Hour), false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, inMaintenanceWindow(windows, tc.at))
+		})
+	}
+}
+
+func TestAppHealth_MaintenanceSchedule(t *testing.T) {
+	h := New(config.AppHealthConfig{
+		ProbeTimeout: time.Second,
+		Threshold:    2,
+		MaintenanceSchedule: []config.AppHealthMaintenanceWindow{
+			{Start: 2 * time.Hour, Duration: time.Hour},
+		},
+	}, func(context.Context) (*Status, error) {
+		return NewStatus(false, nil), nil
+	})
+	clock := clocktesting.NewFakeClock(time.Date(2026, 3, 10, 1, 0, 0, 0, time.UTC))
+	h.clock = clock
+	t.Cleanup(func() { h.Close() })
+
+	// Callbacks run concurrently, so the transitions are checked in the history
+	lastTransition := func(t *testing.T, n int) TransitionEvent {
+		t.Helper()
+		history := h.History()
+		require.Len(t, history, n)
+		return history[n-1]
+	}
+
+	h.setResult(t.Context(), NewStatus(true, nil))
+	h.setResult(t.Context(), NewStatus(false, nil))
+	require.Equal(t, int32(1), h.failureCount.Load())
+
+	// Failures during the window don't count, and the app is reported as healthy
+	clock.Step(time.Hour)
+	for range 5 {
+		h.setResult(t.Context(), NewStatus(false, nil))
+	}
+	assert.True(t, h.IsHealthy())
+	assert.Equal(t, ReasonCodeMaintenance, h.GetStatus().Code)
+	assert.Equal(t, int32(1), h.failureCount.Load())
+	assert.True(t, h.maintenance.Load())
+
+	// Successes still reset the count
+	h.setResult(t.Context(), NewStatus(true, nil))
+	assert.Equal(t, int32(0), h.failureCount.Load())
+
+	// Counting resumes after the window
+	clock.Step(time.Hour)
+	h.setResult(t.Context(), NewStatus(false, nil))
+	h.setResult(t.Context(), NewStatus(false, nil))
+	assert.False(t, h.IsHealthy())
+	assert.False(t, h.maintenance.Load())
+	assert.False(t, lastTransition(t, 2).To.IsHealthy)
+
+	// An app that is unhealthy when the window starts is reported as healthy, even if its probes keep failing
+	clock.Step(23 * time.Hour)
+	h.doProbe(t.Context())
+	assert.True(t, h.maintenance.Load())
+	assert.True(t, h.IsHealthy())
+	event := lastTransition(t, 3)
+	assert.True(t, event.To.IsHealthy)
+	assert.Equal(t, ReasonCodeMaintenance, event.To.Code)
+	h.doProbe(t.Context())
+	assert.True(t, h.IsHealthy())
+	lastTransition(t, 3)
+
+	// Its actual status is reported when the window ends
+	clock.Step(time.Hour)
+	h.doProbe(t.Context())
+	assert.False(t, h.maintenance.Load())
+	assert.False(t, h.IsHealthy())
+	event = lastTransition(t, 4)
+	assert.Equal(t, ReasonCodeMaintenance, event.From.Code)
+	assert.False(t, event.To.IsHealthy)
+
+	t.Run("recovering during the window doesn't deliver a transition", func(t *testing.T) {
+		h.setResult(t.Context(), NewStatus(false, nil))
+		clock.Step(23 * time.Hour)
+		h.doProbe(t.Context())
+		assert.True(t, lastTransition(t, 5).To.IsHealthy)
+
+		h.setResult(t.Context(), NewStatus(true, nil))
+		clock.Step(time.Hour)
+		h.setResult(t.Context(), NewStatus(true, nil))
+		assert.False(t, h.maintenance.Load())
+		assert.True(t, h.IsHealthy())
+		lastTransition(t, 5)
+	})
+
+	t.Run("shutting down during the window", func(t *testing.T) {
+		clock.Step(23 * time.Hour)
+		h.doProbe(t.Context())
+		require.True(t, h.IsHealthy())
+		h.startDraining(t.Context())
+		assert.False(t, h.IsHealthy())
+		event := lastTransition(t, 6)
+		assert.Equal(t, ReasonCodeMaintenance, event.From.Code)
+		assert.Equal(t, ReasonCodeShuttingDown, event.To.Code)
+	})
+}
+
+func TestValidateMaintenanceSchedule(t *testing.T) {
+	base := config.AppHealthConfig{ProbeInterval: time.Second, Threshold: 1}
+	tests := map[string]struct {
+		window config.AppHealthMaintenanceWindow
+		valid  bool
+	}{
+		"valid":             {config.AppHealthMaintenanceWindow{Start: time.Hour, Duration: time.Hour}, true},
+		"whole day":         {config.AppHealthMaintenanceWindow{Duration: 24 * time.Hour}, true},
+		"negative start":    {config.AppHealthMaintenanceWindow{Start: -time.Hour, Duration: time.Hour}, false},
+		"start past a day":  {config.AppHealthMaintenanceWindow{Start: 24 * time.Hour, Duration: time.Hour}, false},
+		"zero duration":     {config.AppHealthMaintenanceWindow{Start: time.Hour}, false},
+		"longer than a day": {config.AppHealthMaintenanceWindow{Duration: 25 * time.Hour}, false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := base
+			c.MaintenanceSchedule = []config.AppHealthMaintenanceWindow{tc.window}
+			err := validateConfig(c)
+			if tc.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"time"
+)
+
+// ndjsonBuffer is the number of lines that can be waiting to be written before new ones are dropped.
+const ndjsonBuffer = 256
+
+// NDJSON events.
+const (
+	// NDJSONEventTransition is the event of the lines written for health transitions.
+	NDJSONEventTransition = "transition"
+	// NDJSONEventProbe is the event of the lines written for probe results, if enabled with WithNDJSONProbes.
+	NDJSONEventProbe = "probe"
+)
+
+// NDJSONRecord is a line written by the output enabled with EnableNDJSON.
+// The names of the fields in the JSON serialization are stable, so log pipelines can parse them.
+type NDJSONRecord struct {
+	// Timestamp is in UTC.
+	Timestamp time.Time `json:"timestamp"`
+	Event     string    `json:"event"`
+	Healthy   bool      `json:"healthy"`
+	Reason    string    `json:"reason,omitempty"`
+	// FailureCount is the number of consecutive failures after the event.
+	FailureCount int32 `json:"failureCount"`
+	// TransitionID is the ID of the transition, for transition events.
+	TransitionID uint64 `json:"transitionId,omitempty"`
+}
+
+// NDJSONOption configures the output enabled with EnableNDJSON.
+type NDJSONOption func(*ndjsonOutput)
+
+// WithNDJSONWriter sets the writer that lines are written to, instead of the standard output.
+func WithNDJSONWriter(w io.Writer) NDJSONOption {
+	return func(o *ndjsonOutput) {
+		o.w = w
+	}
+}
+
+// WithNDJSONProbes writes a line for the result of every probe, in addition to transitions.
+func WithNDJSONProbes() NDJSONOption {
+	return func(o *ndjsonOutput) {
+		o.probes = true
+	}
+}
+
+type ndjsonOutput struct {
+	w      io.Writer
+	probes bool
+	lines  chan []byte
+	stop   chan struct{}
+}
+
+// EnableNDJSON writes a line of JSON, an NDJSONRecord, to the standard output for each health transition, so the app's health can be collected from the logs.
+// Lines are written in order by a background goroutine, so writing doesn't block the probe loop; if the writer falls behind, new lines are dropped.
+// Calling it again replaces the current output; it has no effect once the object is closed.
+func (h *AppHealth) EnableNDJSON(opts ...NDJSONOption) {
+	o := &ndjsonOutput{
+		w:     os.Stdout,
+		lines: make(chan []byte, ndjsonBuffer),
+		stop:  make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if h.closed.Load() {
+		return
+	}
+	if prev := h.ndjson.Swap(o); prev != nil {
+		close(prev.stop)
+	}
+
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+		o.run(h.closeCh)
+	}()
+}
+
+// run writes lines until the output is replaced or the object is closed, and then writes the lines that are still buffered.
+func (o *ndjsonOutput) run(closeCh <-chan struct{}) {
+	for {
+		select {
+		case line := <-o.lines:
+			o.write(line)
+		case <-o.stop:
+			o.flush()
+			return
+		case <-closeCh:
+			o.flush()
+			return
+		}
+	}
+}
+
+func (o *ndjsonOutput) flush() {
+	for {
+		select {
+		case line := <-o.lines:
+			o.write(line)
+		default:
+			return
+		}
+	}
+}
+
+func (o *ndjsonOutput) write(line []byte) {
+	if _, err := o.w.Write(line); err != nil {
+		log.Warnf("Failed to write app health NDJSON record: %v", err)
+	}
+}
+
+// emit queues a record to be written, dropping it if the buffer is full.
+func (o *ndjsonOutput) emit(rec NDJSONRecord) {
+	line, err := json.Marshal(rec)
+	if err != nil {
+		log.Errorf("Failed to serialize app health NDJSON record: %v", err)
+		return
+	}
+	select {
+	case o.lines <- append(line, '\n'):
+	default:
+		log.Debug("Dropping app health NDJSON record because the writer is falling behind")
+	}
+}
+
+// emitTransitionNDJSON writes a line for the transition, if the NDJSON output is enabled.
+func (h *AppHealth) emitTransitionNDJSON(event TransitionEvent) {
+	o := h.ndjson.Load()
+	if o == nil || event.To == nil {
+		return
+	}
+
+	rec := NDJSONRecord{
+		Timestamp:    event.At.UTC(),
+		Event:        NDJSONEventTransition,
+		Healthy:      event.To.IsHealthy,
+		FailureCount: event.FailureCount,
+		TransitionID: event.ID,
+	}
+	switch {
+	case event.To.Reason != nil:
+		rec.Reason = *event.To.Reason
+	case !event.To.IsHealthy:
+		rec.Reason = h.formatReason(event.FailureCount)
+	}
+	o.emit(rec)
+}
+
+// emitProbeNDJSON writes a line for the result of a probe, if the NDJSON output is enabled with WithNDJSONProbes.
+func (h *AppHealth) emitProbeNDJSON(status *Status) {
+	o := h.ndjson.Load()
+	if o == nil || !o.probes || status == nil {
+		return
+	}
+
+	rec := NDJSONRecord{
+		Timestamp:    h.clock.Now().UTC(),
+		Event:        NDJSONEventProbe,
+		Healthy:      status.IsHealthy,
+		FailureCount: h.failureCount.Load(),
+	}
+	if status.Reason != nil {
+		rec.Reason = *status.Reason
+	}
+	o.emit(rec)
+}
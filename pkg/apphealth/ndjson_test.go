@@ -0,0 +1,162 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	"github.com/dapr/dapr/pkg/config"
+)
+
+func TestAppHealth_EnableNDJSON(t *testing.T) {
+	// records closes h, so all buffered lines are written, and parses them
+	records := func(t *testing.T, h *AppHealth, buf *syncBuffer) []NDJSONRecord {
+		t.Helper()
+		require.NoError(t, h.Close())
+		var res []NDJSONRecord
+		for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+			if line == "" {
+				continue
+			}
+			var rec NDJSONRecord
+			require.NoError(t, json.Unmarshal([]byte(line), &rec), line)
+			res = append(res, rec)
+		}
+		return res
+	}
+	newHealth := func(healthy func() bool) *AppHealth {
+		h := New(config.AppHealthConfig{
+			ProbeTimeout: time.Second,
+			Threshold:    1,
+		}, func(context.Context) (*Status, error) {
+			if healthy() {
+				return NewStatus(true, nil), nil
+			}
+			reason := "db down"
+			return NewStatus(false, &reason), nil
+		})
+		h.clock = clocktesting.NewFakeClock(time.Unix(1700000000, 0))
+		return h
+	}
+
+	t.Run("transitions", func(t *testing.T) {
+		healthy := true
+		h := newHealth(func() bool { return healthy })
+		var buf syncBuffer
+		h.EnableNDJSON(WithNDJSONWriter(&buf))
+
+		h.doProbe(t.Context())
+		h.doProbe(t.Context())
+		healthy = false
+		h.doProbe(t.Context())
+
+		recs := records(t, h, &buf)
+		require.Len(t, recs, 2)
+		assert.Equal(t, NDJSONRecord{
+			Timestamp:    time.Unix(1700000000, 0).UTC(),
+			Event:        NDJSONEventTransition,
+			Healthy:      true,
+			TransitionID: 1,
+		}, recs[0])
+		assert.Equal(t, NDJSONRecord{
+			Timestamp:    time.Unix(1700000000, 0).UTC(),
+			Event:        NDJSONEventTransition,
+			Healthy:      false,
+			Reason:       "db down",
+			FailureCount: 1,
+			TransitionID: 2,
+		}, recs[1])
+	})
+
+	t.Run("schema", func(t *testing.T) {
+		h := newHealth(func() bool { return false })
+		h.setResult(t.Context(), NewStatus(true, nil))
+		var buf syncBuffer
+		h.EnableNDJSON(WithNDJSONWriter(&buf))
+		h.setResult(t.Context(), NewStatus(false, nil))
+		require.NoError(t, h.Close())
+
+		var fields map[string]any
+		require.NoError(t, json.Unmarshal([]byte(buf.String()), &fields))
+		assert.Equal(t, map[string]any{
+			"timestamp":    "2023-11-14T22:13:20Z",
+			"event":        "transition",
+			"healthy":      false,
+			"reason":       "App health check failed 1 times",
+			"failureCount": float64(1),
+			"transitionId": float64(2),
+		}, fields)
+	})
+
+	t.Run("probes", func(t *testing.T) {
+		h := newHealth(func() bool { return true })
+		var buf syncBuffer
+		h.EnableNDJSON(WithNDJSONWriter(&buf), WithNDJSONProbes())
+
+		h.doProbe(t.Context())
+		h.doProbe(t.Context())
+
+		recs := records(t, h, &buf)
+		events := make([]string, len(recs))
+		for i, r := range recs {
+			events[i] = r.Event
+		}
+		assert.Equal(t, []string{NDJSONEventTransition, NDJSONEventProbe, NDJSONEventProbe}, events)
+	})
+
+	t.Run("slow writer doesn't block probes", func(t *testing.T) {
+		h := newHealth(func() bool { return true })
+		release := make(chan struct{})
+		h.EnableNDJSON(WithNDJSONWriter(blockingWriter(release)), WithNDJSONProbes())
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for range 2 * ndjsonBuffer {
+				h.doProbe(t.Context())
+			}
+		}()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "Probes blocked by the NDJSON writer")
+		}
+		close(release)
+		require.NoError(t, h.Close())
+	})
+
+	t.Run("no-op after close", func(t *testing.T) {
+		h := newHealth(func() bool { return true })
+		require.NoError(t, h.Close())
+		var buf syncBuffer
+		h.EnableNDJSON(WithNDJSONWriter(&buf))
+		assert.Nil(t, h.ndjson.Load())
+	})
+}
+
+// blockingWriter returns a writer that blocks until release is closed.
+type blockingWriter chan struct{}
+
+func (w blockingWriter) Write(p []byte) (int, error) {
+	<-w
+	return len(p), nil
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"errors"
+)
+
+// PersistFunc is the signature of the function that durably records the app's health, so it can be restored with LoadState after a restart.
+type PersistFunc func(snapshot HealthSnapshot)
+
+// SetPersistFunc sets the function that is invoked with a snapshot of the app's health after transitions.
+// It's invoked in background, at most once per PersistInterval, and once more when the object is closed if a snapshot is pending.
+// Passing nil disables persistence.
+func (h *AppHealth) SetPersistFunc(fn PersistFunc) {
+	h.persistFn.Store(&fn)
+}
+
+// LoadState restores the health of the app from a snapshot, for example one recorded by a PersistFunc before a restart, so the app doesn't start with a cold unhealthy window.
+// It must be invoked before any result is recorded.
+// The restored state isn't delivered to the change callback: callers that need it apply it themselves, for example with the status returned by GetStatus.
+func (h *AppHealth) LoadState(s HealthSnapshot) error {
+	h.runningLock.Lock()
+	defer h.runningLock.Unlock()
+
+	if h.closed.Load() {
+		return ErrClosed
+	}
+	if h.running > 0 || h.determined.Load() {
+		return errors.New("cannot load app health state after a result was recorded")
+	}
+
+	if s.IsHealthy {
+		h.failureCount.Store(0)
+	} else {
+		h.failureCount.Store(max(h.config.Threshold, 1))
+	}
+	h.lastSource.Store(uint32(s.Source))
+	if s.LastReport.IsZero() {
+		h.lastReport.Store(0)
+		h.lastReportAt.Store(nil)
+	} else {
+		h.lastReport.Store(s.LastReport.UnixMicro())
+		// The snapshot has no monotonic reading, so durations since the restored report are measured with the wall clock
+		lastReportAt := s.LastReport
+		h.lastReportAt.Store(&lastReportAt)
+	}
+	// Transition IDs keep increasing across restarts
+	h.transitionSeq.Store(s.LastTransitionID)
+	h.determined.Store(true)
+	log.Infof("Restored app health state: healthy=%v", s.IsHealthy)
+	return nil
+}
+
+func (h *AppHealth) loadPersistFn() PersistFunc {
+	fn := h.persistFn.Load()
+	if fn == nil {
+		return nil
+	}
+	return *fn
+}
+
+// persist invokes the persist function with the state after the transition, unless it was invoked less than PersistInterval ago.
+// In that case, it's invoked with the current state when the interval is over.
+func (h *AppHealth) persist(event TransitionEvent) {
+	fn := h.loadPersistFn()
+	if fn == nil {
+		return
+	}
+
+	h.persistLock.Lock()
+	defer h.persistLock.Unlock()
+
+	if h.persistTimer != nil || h.closed.Load() {
+		// A snapshot will be taken when the timer fires
+		return
+	}
+
+	now := h.clock.Now()
+	if wait := h.config.PersistInterval - now.Sub(h.lastPersist); !h.lastPersist.IsZero() && wait > 0 {
+		h.wg.Add(1)
+		h.persistTimer = h.clock.AfterFunc(wait, func() {
+			// Run in a separate goroutine since some clocks invoke the function while holding their own locks
+			go h.persistPending()
+		})
+		return
+	}
+
+	h.lastPersist = now
+	snapshot := h.Snapshot()
+	// The first result isn't reflected by GetStatus until it's fully applied, so the status is taken from the event
+	if !h.determined.Load() {
+		snapshot.IsHealthy = event.To.IsHealthy
+		snapshot.Reason = event.To.Reason
+		snapshot.Source = event.To.Source
+	}
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+		h.invokePersist(fn, snapshot)
+	}()
+}
+
+// persistPending invokes the persist function for a throttled snapshot, once the interval is over.
+func (h *AppHealth) persistPending() {
+	defer h.wg.Done()
+
+	h.persistLock.Lock()
+	// Close flushed the snapshot already
+	if h.persistTimer == nil {
+		h.persistLock.Unlock()
+		return
+	}
+	h.persistTimer = nil
+	h.lastPersist = h.clock.Now()
+	h.persistLock.Unlock()
+
+	if fn := h.loadPersistFn(); fn != nil {
+		h.invokePersist(fn, h.Snapshot())
+	}
+}
+
+// flushPersist invokes the persist function synchronously if a throttled snapshot is pending.
+func (h *AppHealth) flushPersist() {
+	h.persistLock.Lock()
+	defer h.persistLock.Unlock()
+
+	if h.persistTimer == nil {
+		return
+	}
+	if h.persistTimer.Stop() {
+		h.wg.Done()
+	}
+	h.persistTimer = nil
+
+	if fn := h.loadPersistFn(); fn != nil {
+		h.invokePersist(fn, h.Snapshot())
+	}
+}
+
+// invokePersist invokes the persist function, recovering from panics and counting them as callback failures.
+func (h *AppHealth) invokePersist(fn PersistFunc, snapshot HealthSnapshot) {
+	defer h.recoverCallback()
+	fn(snapshot)
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	"github.com/dapr/dapr/pkg/config"
+)
+
+type recordingPersister struct {
+	lock      sync.Mutex
+	snapshots []HealthSnapshot
+}
+
+func (r *recordingPersister) persist(s HealthSnapshot) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.snapshots = append(r.snapshots, s)
+}
+
+func (r *recordingPersister) get() []HealthSnapshot {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]HealthSnapshot(nil), r.snapshots...)
+}
+
+func TestAppHealth_PersistFunc(t *testing.T) {
+	clock := clocktesting.NewFakeClock(time.Now())
+	h := New(config.AppHealthConfig{
+		Threshold:       1,
+		PersistInterval: time.Minute,
+	}, nil)
+	h.clock = clock
+
+	p := &recordingPersister{}
+	h.SetPersistFunc(p.persist)
+
+	// The first transition is persisted right away
+	h.setResult(t.Context(), NewStatus(true, nil))
+	require.Eventually(t, func() bool {
+		return len(p.get()) == 1
+	}, time.Second, time.Millisecond)
+	assert.True(t, p.get()[0].IsHealthy)
+
+	// Transitions within the interval are coalesced, and the latest state is persisted when it's over
+	h.setResult(t.Context(), NewStatus(false, nil))
+	h.setResult(t.Context(), NewStatus(true, nil))
+	h.setResult(t.Context(), NewStatus(false, nil))
+	assert.Len(t, p.get(), 1)
+	clock.Step(time.Minute)
+	require.Eventually(t, func() bool {
+		return len(p.get()) == 2
+	}, time.Second, time.Millisecond)
+	last := p.get()[1]
+	assert.False(t, last.IsHealthy)
+	assert.Equal(t, uint64(4), last.LastTransitionID)
+
+	// Close flushes a pending snapshot
+	h.setResult(t.Context(), NewStatus(true, nil))
+	assert.Len(t, p.get(), 2)
+	require.NoError(t, h.Close())
+	snapshots := p.get()
+	require.Len(t, snapshots, 3)
+	assert.True(t, snapshots[2].IsHealthy)
+	assert.Equal(t, uint64(5), snapshots[2].LastTransitionID)
+}
+
+func TestAppHealth_PersistFuncPanics(t *testing.T) {
+	clock := clocktesting.NewFakeClock(time.Now())
+	h := New(config.AppHealthConfig{
+		Threshold:       1,
+		PersistInterval: time.Minute,
+	}, nil)
+	h.clock = clock
+
+	var calls atomic.Int32
+	h.SetPersistFunc(func(HealthSnapshot) {
+		calls.Add(1)
+		panic("boom")
+	})
+
+	h.setResult(t.Context(), NewStatus(true, nil))
+	require.Eventually(t, func() bool {
+		return h.CallbackFailureCount() == 1
+	}, time.Second, time.Millisecond)
+
+	// Throttled snapshots are recovered from when the interval is over
+	h.setResult(t.Context(), NewStatus(false, nil))
+	clock.Step(time.Minute)
+	require.Eventually(t, func() bool {
+		return h.CallbackFailureCount() == 2
+	}, time.Second, time.Millisecond)
+
+	// And when Close flushes them
+	h.setResult(t.Context(), NewStatus(true, nil))
+	require.NotPanics(t, func() {
+		require.NoError(t, h.Close())
+	})
+	assert.Equal(t, int32(3), calls.Load())
+	assert.Equal(t, int64(3), h.CallbackFailureCount())
+}
+
+func TestAppHealth_LoadState(t *testing.T) {
+	t.Run("restores a healthy state", func(t *testing.T) {
+		h := New(config.AppHealthConfig{
+			Threshold: 3,
+		}, nil)
+		t.Cleanup(func() { h.Close() })
+
+		lastReport := time.Now().Add(-time.Second)
+		require.NoError(t, h.LoadState(HealthSnapshot{
+			IsHealthy:        true,
+			LastReport:       lastReport,
+			Source:           StatusSourceProbe,
+			LastTransitionID: 7,
+		}))
+		assert.True(t, h.IsHealthy())
+		assert.Equal(t, StatusSourceProbe, h.Source())
+		assert.Equal(t, lastReport.UnixMicro(), h.Snapshot().LastReport.UnixMicro())
+		since, ok := h.TimeSinceLastReport()
+		require.True(t, ok)
+		assert.GreaterOrEqual(t, since, time.Second)
+		assert.Less(t, since, time.Minute)
+
+		// Transition IDs continue from the restored one
+		h.setResult(t.Context(), NewStatus(false, nil))
+		h.setResult(t.Context(), NewStatus(false, nil))
+		h.setResult(t.Context(), NewStatus(false, nil))
+		assert.False(t, h.IsHealthy())
+		assert.Equal(t, uint64(8), h.Snapshot().LastTransitionID)
+	})
+
+	t.Run("restores an unhealthy state", func(t *testing.T) {
+		h := New(config.AppHealthConfig{
+			Threshold: 3,
+		}, nil)
+		t.Cleanup(func() { h.Close() })
+
+		require.NoError(t, h.LoadState(HealthSnapshot{IsHealthy: false}))
+		status := h.GetStatus()
+		assert.False(t, status.IsHealthy)
+		assert.NotEqual(t, ReasonCodeNotYetProbed, status.Code)
+
+		h.setResult(t.Context(), NewStatus(true, nil))
+		assert.True(t, h.IsHealthy())
+	})
+
+	t.Run("fails after a result was recorded", func(t *testing.T) {
+		h := New(config.AppHealthConfig{
+			Threshold: 1,
+		}, nil)
+		t.Cleanup(func() { h.Close() })
+
+		h.setResult(t.Context(), NewStatus(true, nil))
+		require.Error(t, h.LoadState(HealthSnapshot{IsHealthy: false}))
+		assert.True(t, h.IsHealthy())
+	})
+
+	t.Run("fails when closed", func(t *testing.T) {
+		h := New(config.AppHealthConfig{}, nil)
+		h.Close()
+		require.Error(t, h.LoadState(HealthSnapshot{IsHealthy: true}))
+	})
+}
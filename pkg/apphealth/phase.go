@@ -0,0 +1,75 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import "fmt"
+
+// Phase is the step the probe loop is currently performing.
+type Phase uint8
+
+const (
+	// PhaseIdle indicates the probe loop hasn't been started.
+	PhaseIdle Phase = iota
+	// PhaseWaitingForTick indicates the probe loop is waiting for the next probe or report.
+	PhaseWaitingForTick
+	// PhaseProbing indicates the probe loop is running a probe.
+	PhaseProbing
+	// PhaseReportingResult indicates the probe loop is applying a status reported by the app.
+	PhaseReportingResult
+	// PhaseStopped indicates the probe loop has stopped.
+	PhaseStopped
+)
+
+var phaseNames = map[Phase]string{
+	PhaseIdle:            "idle",
+	PhaseWaitingForTick:  "waiting-for-tick",
+	PhaseProbing:         "probing",
+	PhaseReportingResult: "reporting-result",
+	PhaseStopped:         "stopped",
+}
+
+func (p Phase) String() string {
+	if n, ok := phaseNames[p]; ok {
+		return n
+	}
+	return "unknown"
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (p Phase) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (p *Phase) UnmarshalText(text []byte) error {
+	name := string(text)
+	for v, n := range phaseNames {
+		if n == name {
+			*p = v
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid phase: %q", name)
+}
+
+// Phase returns what the probe loop is currently doing.
+// This is meant for diagnostics, for example to detect a probe that is hung.
+func (h *AppHealth) Phase() Phase {
+	//nolint:gosec
+	return Phase(h.phase.Load())
+}
+
+func (h *AppHealth) setPhase(p Phase) {
+	h.phase.Store(uint32(p))
+}
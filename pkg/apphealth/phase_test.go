@@ -0,0 +1,81 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	"github.com/dapr/dapr/pkg/config"
+)
+
+func TestPhaseText(t *testing.T) {
+	for _, p := range []Phase{PhaseIdle, PhaseWaitingForTick, PhaseProbing, PhaseReportingResult, PhaseStopped} {
+		t.Run(p.String(), func(t *testing.T) {
+			text, err := p.MarshalText()
+			require.NoError(t, err)
+
+			var parsed Phase
+			require.NoError(t, parsed.UnmarshalText(text))
+			assert.Equal(t, p, parsed)
+		})
+	}
+
+	assert.Equal(t, "unknown", Phase(200).String())
+	var parsed Phase
+	require.Error(t, parsed.UnmarshalText([]byte("unknown")))
+}
+
+func TestAppHealth_Phase(t *testing.T) {
+	probing := make(chan struct{})
+	release := make(chan struct{})
+	h := New(config.AppHealthConfig{
+		ProbeInterval: time.Second,
+		ProbeTimeout:  time.Second,
+		Threshold:     1,
+	}, func(context.Context) (*Status, error) {
+		close(probing)
+		<-release
+		return NewStatus(true, nil), nil
+	})
+	clock := clocktesting.NewFakeClock(time.Now())
+	h.clock = clock
+
+	assert.Equal(t, PhaseIdle, h.Phase())
+
+	require.NoError(t, h.StartProbes(t.Context()))
+	assert.Eventually(t, func() bool {
+		return h.Phase() == PhaseWaitingForTick
+	}, time.Second, time.Microsecond)
+
+	assert.Eventually(t, clock.HasWaiters, time.Second, time.Microsecond)
+	clock.Step(time.Second)
+	<-probing
+	assert.Eventually(t, func() bool {
+		return h.Phase() == PhaseProbing
+	}, time.Second, time.Microsecond)
+
+	close(release)
+	assert.Eventually(t, func() bool {
+		return h.Phase() == PhaseWaitingForTick
+	}, time.Second, time.Microsecond)
+
+	require.NoError(t, h.Close())
+	assert.Equal(t, PhaseStopped, h.Phase())
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"time"
+)
+
+// maxFileMarkerContent is the maximum size of a marker file whose content is compared with the expected value.
+const maxFileMarkerContent = 4 << 10
+
+// FileMarkerProbeOption configures a probe created with NewFileMarkerProbe.
+type FileMarkerProbeOption func(*fileMarkerProbe)
+
+type fileMarkerProbe struct {
+	path     string
+	expected []byte
+	maxAge   time.Duration
+}
+
+// WithExpectedContent requires the marker file to contain exactly content, ignoring leading and trailing whitespace.
+func WithExpectedContent(content string) FileMarkerProbeOption {
+	return func(p *fileMarkerProbe) {
+		p.expected = bytes.TrimSpace([]byte(content))
+	}
+}
+
+// WithMaxAge requires the marker file to have been modified within maxAge, so apps can signal liveness by touching it periodically.
+func WithMaxAge(maxAge time.Duration) FileMarkerProbeOption {
+	return func(p *fileMarkerProbe) {
+		p.maxAge = maxAge
+	}
+}
+
+// NewFileMarkerProbe returns a ProbeFunction that reports the app as healthy when the file at path exists.
+// A missing file, unexpected content, or a stale file are reported as unhealthy; other errors accessing the file are returned as errors.
+// Returns an error if path is empty.
+func NewFileMarkerProbe(path string, opts ...FileMarkerProbeOption) (ProbeFunction, error) {
+	if path == "" {
+		return nil, errors.New("marker file path for health probe is empty")
+	}
+
+	p := &fileMarkerProbe{
+		path: path,
+	}
+	for _, o := range opts {
+		o(p)
+	}
+
+	return p.probe, nil
+}
+
+func (p *fileMarkerProbe) probe(context.Context) (*Status, error) {
+	fi, err := os.Stat(p.path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		reason := "Marker file " + p.path + " does not exist"
+		return NewStatus(false, &reason), nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to stat marker file %s: %w", p.path, err)
+	case fi.IsDir():
+		reason := p.path + " is a directory"
+		return NewStatus(false, &reason), nil
+	}
+
+	if p.maxAge > 0 {
+		if age := time.Since(fi.ModTime()); age > p.maxAge {
+			reason := fmt.Sprintf("Marker file %s was last modified %v ago, which is more than %v", p.path, age.Truncate(time.Second), p.maxAge)
+			return NewStatus(false, &reason), nil
+		}
+	}
+
+	if p.expected != nil {
+		if fi.Size() > maxFileMarkerContent {
+			reason := fmt.Sprintf("Marker file %s is larger than %d bytes", p.path, maxFileMarkerContent)
+			return NewStatus(false, &reason), nil
+		}
+		content, err := os.ReadFile(p.path)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			// Deleted after the stat
+			reason := "Marker file " + p.path + " does not exist"
+			return NewStatus(false, &reason), nil
+		case err != nil:
+			return nil, fmt.Errorf("failed to read marker file %s: %w", p.path, err)
+		}
+		if !bytes.Equal(bytes.TrimSpace(content), p.expected) {
+			reason := "Marker file " + p.path + " doesn't have the expected content"
+			return NewStatus(false, &reason), nil
+		}
+	}
+
+	return NewStatus(true, nil), nil
+}
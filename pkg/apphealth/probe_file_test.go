@@ -0,0 +1,79 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFileMarkerProbe(t *testing.T) {
+	_, err := NewFileMarkerProbe("")
+	require.Error(t, err)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "ready")
+
+	probe := func(t *testing.T, opts ...FileMarkerProbeOption) *Status {
+		t.Helper()
+		fn, err := NewFileMarkerProbe(path, opts...)
+		require.NoError(t, err)
+		status, err := fn(t.Context())
+		require.NoError(t, err)
+		return status
+	}
+
+	t.Run("missing file is unhealthy", func(t *testing.T) {
+		status := probe(t)
+		assert.False(t, status.IsHealthy)
+		require.NotNil(t, status.Reason)
+		assert.Contains(t, *status.Reason, "does not exist")
+	})
+
+	require.NoError(t, os.WriteFile(path, []byte("ok\n"), 0o600))
+
+	t.Run("existing file is healthy", func(t *testing.T) {
+		assert.True(t, probe(t).IsHealthy)
+	})
+
+	t.Run("expected content", func(t *testing.T) {
+		assert.True(t, probe(t, WithExpectedContent("ok")).IsHealthy)
+		status := probe(t, WithExpectedContent("ready"))
+		assert.False(t, status.IsHealthy)
+		assert.Contains(t, *status.Reason, "expected content")
+	})
+
+	t.Run("max age", func(t *testing.T) {
+		assert.True(t, probe(t, WithMaxAge(time.Minute)).IsHealthy)
+
+		old := time.Now().Add(-time.Hour)
+		require.NoError(t, os.Chtimes(path, old, old))
+		status := probe(t, WithMaxAge(time.Minute))
+		assert.False(t, status.IsHealthy)
+		assert.Contains(t, *status.Reason, "last modified")
+	})
+
+	t.Run("directory is unhealthy", func(t *testing.T) {
+		fn, err := NewFileMarkerProbe(dir)
+		require.NoError(t, err)
+		status, err := fn(t.Context())
+		require.NoError(t, err)
+		assert.False(t, status.IsHealthy)
+	})
+}
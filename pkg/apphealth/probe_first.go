@@ -0,0 +1,68 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// NewFirstHealthyProbe returns a ProbeFunction that runs probes in order, stopping at the first one that reports the app as healthy and returning its status.
+// This suits apps that expose one of several health endpoints depending on their configuration, without probing the others once one succeeds.
+// The app is unhealthy only if all probes fail, in which case the reasons of all the attempts are included. Errors returned by the probes, or a nil status, are counted as failures.
+// All attempts share the context, so remaining probes are skipped once its deadline is exceeded.
+// Returns an error if there are no probes or if any of them is nil.
+func NewFirstHealthyProbe(probes ...ProbeFunction) (ProbeFunction, error) {
+	if len(probes) == 0 {
+		return nil, errors.New("first-healthy probe has no probes")
+	}
+	for i, fn := range probes {
+		if fn == nil {
+			return nil, fmt.Errorf("probe function %d is nil", i+1)
+		}
+	}
+	probes = append([]ProbeFunction(nil), probes...)
+
+	return func(ctx context.Context) (*Status, error) {
+		reasons := make([]string, 0, len(probes))
+		for i, fn := range probes {
+			if err := ctx.Err(); err != nil {
+				reasons = append(reasons, fmt.Sprintf("probe %d: not attempted: %v", i+1, err))
+				break
+			}
+
+			status, err := fn(ctx)
+			if err != nil {
+				status = NewStatusWithCause("Probe error", err)
+			} else if status == nil {
+				noStatus := "Probe returned no status"
+				status = NewStatus(false, &noStatus)
+			}
+			if status.IsHealthy {
+				return status, nil
+			}
+
+			reason := "unhealthy"
+			if status.Reason != nil {
+				reason = *status.Reason
+			}
+			reasons = append(reasons, fmt.Sprintf("probe %d: %s", i+1, reason))
+		}
+
+		reason := "No probe succeeded: " + strings.Join(reasons, "; ")
+		return NewStatus(false, &reason), nil
+	}, nil
+}
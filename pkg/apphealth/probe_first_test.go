@@ -0,0 +1,106 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFirstHealthyProbe(t *testing.T) {
+	_, err := NewFirstHealthyProbe()
+	require.Error(t, err)
+	_, err = NewFirstHealthyProbe(nil)
+	require.Error(t, err)
+
+	var calls [3]atomic.Int32
+	results := [3]*Status{}
+	probe := func(i int) ProbeFunction {
+		return func(context.Context) (*Status, error) {
+			calls[i].Add(1)
+			if results[i] == nil {
+				return nil, errors.New("not supported")
+			}
+			return results[i], nil
+		}
+	}
+	fn, err := NewFirstHealthyProbe(probe(0), probe(1), probe(2))
+	require.NoError(t, err)
+
+	t.Run("stops at the first healthy probe", func(t *testing.T) {
+		grpcReason := "gRPC health not served"
+		results[0] = NewStatus(false, &grpcReason)
+		results[1] = NewStatus(true, nil)
+		status, err := fn(t.Context())
+		require.NoError(t, err)
+		assert.True(t, status.IsHealthy)
+		assert.Equal(t, int32(1), calls[0].Load())
+		assert.Equal(t, int32(1), calls[1].Load())
+		assert.Equal(t, int32(0), calls[2].Load())
+	})
+
+	t.Run("unhealthy if all fail", func(t *testing.T) {
+		results[1] = NewStatus(false, nil)
+		results[2] = nil
+		status, err := fn(t.Context())
+		require.NoError(t, err)
+		assert.False(t, status.IsHealthy)
+		require.NotNil(t, status.Reason)
+		assert.Equal(t, "No probe succeeded: probe 1: gRPC health not served; probe 2: unhealthy; probe 3: Probe error: not supported", *status.Reason)
+	})
+
+	t.Run("nil status is a failure", func(t *testing.T) {
+		fn, err := NewFirstHealthyProbe(func(context.Context) (*Status, error) {
+			return nil, nil
+		}, func(context.Context) (*Status, error) {
+			return NewStatus(true, nil), nil
+		})
+		require.NoError(t, err)
+
+		status, err := fn(t.Context())
+		require.NoError(t, err)
+		assert.True(t, status.IsHealthy)
+
+		fn, err = NewFirstHealthyProbe(func(context.Context) (*Status, error) {
+			return nil, nil
+		})
+		require.NoError(t, err)
+		status, err = fn(t.Context())
+		require.NoError(t, err)
+		assert.False(t, status.IsHealthy)
+		assert.Equal(t, "No probe succeeded: probe 1: Probe returned no status", *status.Reason)
+	})
+
+	t.Run("stops when the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		fn, err := NewFirstHealthyProbe(func(context.Context) (*Status, error) {
+			cancel()
+			return NewStatus(false, nil), nil
+		}, func(context.Context) (*Status, error) {
+			require.Fail(t, "second probe must not run")
+			return nil, nil
+		})
+		require.NoError(t, err)
+
+		status, err := fn(ctx)
+		require.NoError(t, err)
+		assert.False(t, status.IsHealthy)
+		assert.Equal(t, "No probe succeeded: probe 1: unhealthy; probe 2: not attempted: context canceled", *status.Reason)
+	})
+}
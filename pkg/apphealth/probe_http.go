@@ -0,0 +1,478 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// maxHTTPProbeResponseBody is the maximum number of bytes of the response body that can be included in the reason.
+const maxHTTPProbeResponseBody = 4 << 10
+
+// HTTPProbeOption configures a probe created with NewHTTPProbe.
+type HTTPProbeOption func(*httpProbe)
+
+type httpProbe struct {
+	url string
+	// targetFn resolves the URL before each probe, if set.
+	targetFn     func(ctx context.Context) (string, error)
+	client       *http.Client
+	maxBodyBytes int64
+	tlsConfig    *tls.Config
+	proxy        string
+	// successReason makes healthy statuses include the status code and latency as reason.
+	successReason bool
+	// warmupAfter is the idle time after which a warmup request is sent before the probe.
+	warmupAfter time.Duration
+	// lastProbe is the time the last probe started, in Unix nanoseconds.
+	lastProbe atomic.Int64
+	// healthyCodes are the ranges of status codes that are considered healthy; 2xx if empty.
+	healthyCodes []statusCodeRange
+	// tokenSource returns the bearer token attached to each request, if set.
+	tokenSource TokenSource
+	// connectTimeout and responseTimeout bound connecting to the app and waiting for the response headers, within the probe's context.
+	connectTimeout  time.Duration
+	responseTimeout time.Duration
+}
+
+// TokenSource is the signature of the function that returns the bearer token sent with each probe request.
+// It's invoked before each probe, so short-lived tokens can be rotated without recreating the probe.
+type TokenSource func(ctx context.Context) (string, error)
+
+// statusCodeRange is an inclusive range of HTTP status codes.
+type statusCodeRange struct {
+	min, max int
+}
+
+// WithHTTPClient sets the client used to perform the probe requests.
+// By default, http.DefaultClient is used.
+func WithHTTPClient(client *http.Client) HTTPProbeOption {
+	return func(p *httpProbe) {
+		p.client = client
+	}
+}
+
+// WithTLSConfig sets the TLS configuration used to connect to the app, for example to present a client certificate when the health endpoint requires mTLS.
+// The probe uses a dedicated transport with this configuration, so it can't be combined with WithHTTPClient.
+// To reload certificates without recreating the probe, set GetClientCertificate in the config or use WithGetClientCertificate.
+func WithTLSConfig(cfg *tls.Config) HTTPProbeOption {
+	return func(p *httpProbe) {
+		p.tlsConfig = cfg.Clone()
+	}
+}
+
+// WithGetClientCertificate sets the callback that returns the client certificate for each TLS handshake, allowing certificates to be rotated.
+// It can be combined with WithTLSConfig, in which case it overrides the config's GetClientCertificate.
+func WithGetClientCertificate(fn func(*tls.CertificateRequestInfo) (*tls.Certificate, error)) HTTPProbeOption {
+	return func(p *httpProbe) {
+		if p.tlsConfig == nil {
+			p.tlsConfig = &tls.Config{
+				MinVersion: tls.VersionTLS12,
+			}
+		}
+		p.tlsConfig.GetClientCertificate = fn
+	}
+}
+
+// WithProxy sends the probe requests through the proxy at proxyURL, which can use the http, https, or socks5 scheme.
+// Like WithTLSConfig, it can't be combined with WithHTTPClient.
+func WithProxy(proxyURL string) HTTPProbeOption {
+	return func(p *httpProbe) {
+		p.proxy = proxyURL
+	}
+}
+
+// WithConnectTimeout sets the time allowed to resolve the app's address and connect to it, so an unreachable app fails fast.
+// If unset, connecting is only bounded by the probe timeout.
+// Like WithTLSConfig, it can't be combined with WithHTTPClient.
+func WithConnectTimeout(d time.Duration) HTTPProbeOption {
+	return func(p *httpProbe) {
+		p.connectTimeout = d
+	}
+}
+
+// WithResponseTimeout sets the time allowed for the app to send the response headers once the request is sent.
+// If unset, waiting for the response is only bounded by the probe timeout.
+// Like WithTLSConfig, it can't be combined with WithHTTPClient.
+func WithResponseTimeout(d time.Duration) HTTPProbeOption {
+	return func(p *httpProbe) {
+		p.responseTimeout = d
+	}
+}
+
+// WithSuccessReason sets the reason of healthy statuses to the response's status code and latency, for example "HTTP 200 in 12ms".
+// By default, healthy statuses have no reason.
+func WithSuccessReason() HTTPProbeOption {
+	return func(p *httpProbe) {
+		p.successReason = true
+	}
+}
+
+// WithHealthyStatusCodes sets the status codes that are considered healthy, replacing the default 200-299 range.
+// If any 3xx code is healthy, the probe doesn't follow redirects; clients set with WithHTTPClient must be configured not to follow them.
+// It can be combined with WithHealthyStatusRange; a code is healthy if it matches any of them.
+func WithHealthyStatusCodes(codes ...int) HTTPProbeOption {
+	return func(p *httpProbe) {
+		for _, c := range codes {
+			p.healthyCodes = append(p.healthyCodes, statusCodeRange{min: c, max: c})
+		}
+	}
+}
+
+// WithHealthyStatusRange sets the inclusive range of status codes that are considered healthy, replacing the default 200-299 range.
+// It can be passed multiple times and combined with WithHealthyStatusCodes.
+func WithHealthyStatusRange(minCode, maxCode int) HTTPProbeOption {
+	return func(p *httpProbe) {
+		p.healthyCodes = append(p.healthyCodes, statusCodeRange{min: minCode, max: maxCode})
+	}
+}
+
+// WithTokenSource sends the token returned by ts as a bearer token in the Authorization header of each request.
+// If ts returns an error, the probe doesn't send the request and reports the app as unhealthy with ReasonCodeTokenUnavailable.
+func WithTokenSource(ts TokenSource) HTTPProbeOption {
+	return func(p *httpProbe) {
+		p.tokenSource = ts
+	}
+}
+
+// WithWarmup sends a HEAD request to the target before the probe when no probe has run for at least idle, ignoring its result.
+// This helps when the app is behind a connection-pooling proxy or load balancer that closes idle connections, so the first probe after a long interval (or after backing off) would otherwise include the connection setup and could time out.
+// The warmup request shares the probe's context, so it counts towards the probe timeout.
+func WithWarmup(idle time.Duration) HTTPProbeOption {
+	return func(p *httpProbe) {
+		p.warmupAfter = idle
+	}
+}
+
+// WithResponseBody includes up to maxBytes of the response body in the reason when the probe fails.
+// The body is only read for non-successful responses, and the value is capped at 4KiB.
+func WithResponseBody(maxBytes int64) HTTPProbeOption {
+	return func(p *httpProbe) {
+		p.maxBodyBytes = min(maxBytes, maxHTTPProbeResponseBody)
+	}
+}
+
+// NewHTTPProbe returns a ProbeFunction that performs a GET request to target.
+// The app is healthy when the response has a 2xx status code, unless configured otherwise with WithHealthyStatusCodes or WithHealthyStatusRange; network errors are reported as unhealthy.
+// Returns an error if target is not a valid http or https URL.
+func NewHTTPProbe(target string, opts ...HTTPProbeOption) (ProbeFunction, error) {
+	if err := validateProbeURL(target); err != nil {
+		return nil, err
+	}
+	return newHTTPProbe(&httpProbe{url: target}, opts)
+}
+
+// NewDynamicHTTPProbe is like NewHTTPProbe, but the target is resolved by calling targetFn before each probe, for apps whose address can change at runtime.
+// Errors returned by targetFn, as well as invalid URLs, are reported as unhealthy.
+func NewDynamicHTTPProbe(targetFn func(ctx context.Context) (string, error), opts ...HTTPProbeOption) (ProbeFunction, error) {
+	if targetFn == nil {
+		return nil, errors.New("target function for health probe is nil")
+	}
+	return newHTTPProbe(&httpProbe{targetFn: targetFn}, opts)
+}
+
+// validateProbeURL returns an error if target is not a valid http or https URL.
+func validateProbeURL(target string) error {
+	u, err := url.Parse(target)
+	if err != nil {
+		return fmt.Errorf("invalid health probe URL %q: %w", target, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid health probe URL %q: scheme must be http or https", target)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("invalid health probe URL %q: missing host", target)
+	}
+	return nil
+}
+
+func newHTTPProbe(p *httpProbe, opts []HTTPProbeOption) (ProbeFunction, error) {
+	p.client = http.DefaultClient
+	for _, o := range opts {
+		o(p)
+	}
+	if p.client == nil {
+		return nil, errors.New("HTTP client for health probe is nil")
+	}
+	customClient := p.client != http.DefaultClient
+	if p.connectTimeout < 0 || p.responseTimeout < 0 {
+		return nil, errors.New("timeouts for health probe must not be negative")
+	}
+	if p.tlsConfig != nil || p.proxy != "" || p.connectTimeout > 0 || p.responseTimeout > 0 {
+		if customClient {
+			return nil, errors.New("TLS config, proxy, or timeouts for health probe can't be combined with a custom HTTP client")
+		}
+		proxy := http.ProxyFromEnvironment
+		if p.proxy != "" {
+			pu, err := parseProxyURL(p.proxy, "http", "https", "socks5")
+			if err != nil {
+				return nil, err
+			}
+			proxy = http.ProxyURL(pu)
+		}
+		p.client = newProbeClient(p.tlsConfig, proxy, p.connectTimeout, p.responseTimeout)
+	}
+	var healthyRedirects bool
+	for _, r := range p.healthyCodes {
+		if r.min < 100 || r.max > 599 || r.min > r.max {
+			return nil, fmt.Errorf("invalid healthy status codes for health probe: %d-%d", r.min, r.max)
+		}
+		if r.min < 400 && r.max >= 300 {
+			healthyRedirects = true
+		}
+	}
+	// For 3xx responses to be seen by the probe, redirects must not be followed
+	// Custom clients are left as-is
+	if healthyRedirects && !customClient {
+		if p.client == http.DefaultClient {
+			p.client = &http.Client{}
+		}
+		p.client.CheckRedirect = func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+	}
+
+	return p.probe, nil
+}
+
+// parseProxyURL parses a proxy URL, checking that it uses one of the allowed schemes.
+func parseProxyURL(proxyURL string, schemes ...string) (*url.URL, error) {
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL for health probe: %w", err)
+	}
+	if !slices.Contains(schemes, u.Scheme) {
+		return nil, fmt.Errorf("invalid proxy URL for health probe %q: scheme must be one of %s", u.Redacted(), strings.Join(schemes, ", "))
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL for health probe %q: missing host", u.Redacted())
+	}
+	return u, nil
+}
+
+// newProbeClient returns a client with a dedicated transport that uses the TLS config, proxy, and timeouts.
+// Requests are bounded by the probe's context, so the transport only sets timeouts for connecting and for the response headers if they're set; the TLS handshake is bounded by the probe's context alone.
+func newProbeClient(cfg *tls.Config, proxy func(*http.Request) (*url.URL, error), connectTimeout, responseTimeout time.Duration) *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			Proxy:                 proxy,
+			DialContext:           (&net.Dialer{Timeout: connectTimeout, KeepAlive: 30 * time.Second}).DialContext,
+			TLSClientConfig:       cfg,
+			ResponseHeaderTimeout: responseTimeout,
+			MaxIdleConns:          1,
+			IdleConnTimeout:       90 * time.Second,
+			ExpectContinueTimeout: time.Second,
+			ForceAttemptHTTP2:     true,
+		},
+	}
+}
+
+func (p *httpProbe) probe(ctx context.Context) (*Status, error) {
+	target := p.url
+	if p.targetFn != nil {
+		var err error
+		target, err = p.targetFn(ctx)
+		if err == nil {
+			err = validateProbeURL(target)
+		}
+		if err != nil {
+			return NewStatusWithCause("Failed to resolve health probe target", err), nil
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	var token string
+	if p.tokenSource != nil {
+		token, err = p.tokenSource(ctx)
+		if err != nil {
+			// Logged separately so authentication problems aren't mistaken for the app being down
+			log.Warnf("Failed to fetch the token for the app health probe: %v", err)
+			status := NewStatusWithCause("Failed to fetch health probe token", err)
+			status.Code = ReasonCodeTokenUnavailable
+			status.RemediationHint = "Check the configuration of the health probe token source"
+			return status, nil
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	start := time.Now()
+	if last := p.lastProbe.Swap(start.UnixNano()); p.warmupAfter > 0 && last > 0 && start.Sub(time.Unix(0, last)) >= p.warmupAfter {
+		p.warmup(ctx, target, token)
+		start = time.Now()
+	}
+	res, err := p.client.Do(req)
+	if err != nil {
+		// Errors here are network-level errors, so we are not returning them as errors
+		status := NewStatusWithCause("Network error", err)
+		status.Code = networkErrorCode(ctx, err)
+		status.FailureClass = FailureClassTransient
+		status.RemediationHint = networkRemediationHint(status.Code, req.URL.Host)
+		return status, nil
+	}
+	defer res.Body.Close()
+
+	if p.isHealthyCode(res.StatusCode) {
+		// Drain before closing
+		_, _ = io.Copy(io.Discard, res.Body)
+		if p.successReason {
+			reason := fmt.Sprintf("HTTP %d in %v", res.StatusCode, time.Since(start).Round(time.Millisecond))
+			return NewStatus(true, &reason), nil
+		}
+		return NewStatus(true, nil), nil
+	}
+
+	reason := fmt.Sprintf("Health check failed with status code: %d", res.StatusCode)
+	if body := p.readBody(res.Body); body != "" {
+		reason += ": " + body
+	}
+	_, _ = io.Copy(io.Discard, res.Body)
+	status := NewStatus(false, &reason)
+	status.RetryAfter = parseRetryAfter(res.Header.Get("Retry-After"))
+	status.FailureClass = httpFailureClass(res.StatusCode)
+	status.RemediationHint = httpRemediationHint(status.FailureClass, req.URL.Path, res.StatusCode)
+	return status, nil
+}
+
+// networkRemediationHint returns the remediation hint for a request that failed with the given reason code.
+func networkRemediationHint(code ReasonCode, host string) string {
+	switch code {
+	case ReasonCodeUnreachable:
+		return "Check that the app is running and listening on " + host
+	case ReasonCodeSlowResponse:
+		return "Check the app logs; it didn't respond within the probe timeout"
+	default:
+		return "Check the network connectivity to " + host
+	}
+}
+
+// httpRemediationHint returns the remediation hint for an unhealthy response.
+func httpRemediationHint(class FailureClass, path string, code int) string {
+	if path == "" {
+		path = "/"
+	}
+	if class == FailureClassPermanent {
+		return fmt.Sprintf("Check that the app serves GET requests on the health endpoint %s, which returned %d", path, code)
+	}
+	return fmt.Sprintf("Check the app logs; endpoint %s returned %d", path, code)
+}
+
+// networkErrorCode returns the reason code for a request error: ReasonCodeUnreachable if the app couldn't be connected to, or ReasonCodeSlowResponse if it didn't respond in time after connecting.
+func networkErrorCode(ctx context.Context, err error) ReasonCode {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return ReasonCodeUnreachable
+	}
+	var netErr net.Error
+	if (errors.As(err, &netErr) && netErr.Timeout()) || ctx.Err() != nil {
+		return ReasonCodeSlowResponse
+	}
+	return ""
+}
+
+// httpFailureClass classifies an unhealthy response: status codes indicating that the health endpoint can never succeed as configured are permanent, others are transient.
+func httpFailureClass(code int) FailureClass {
+	switch code {
+	case http.StatusMethodNotAllowed, http.StatusNotImplemented, http.StatusHTTPVersionNotSupported:
+		return FailureClassPermanent
+	default:
+		return FailureClassTransient
+	}
+}
+
+// isHealthyCode returns true if the status code is in one of the configured healthy ranges, or is 2xx if none are configured.
+func (p *httpProbe) isHealthyCode(code int) bool {
+	if len(p.healthyCodes) == 0 {
+		return code >= 200 && code < 300
+	}
+	for _, r := range p.healthyCodes {
+		if code >= r.min && code <= r.max {
+			return true
+		}
+	}
+	return false
+}
+
+// warmup sends a HEAD request to prime the connection; errors are ignored since the probe that follows reports them.
+func (p *httpProbe) warmup(ctx context.Context, target string, token string) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, target, nil)
+	if err != nil {
+		return
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	res, err := p.client.Do(req)
+	if err != nil {
+		log.Debugf("App health warmup request failed: %v", err)
+		return
+	}
+	_, _ = io.Copy(io.Discard, res.Body)
+	res.Body.Close()
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which can be either a number of seconds or a HTTP date.
+// Returns 0 if the value is empty or invalid.
+func parseRetryAfter(val string) time.Duration {
+	if val == "" {
+		return 0
+	}
+	if secs, err := strconv.ParseInt(val, 10, 64); err == nil {
+		if secs <= 0 || secs > math.MaxInt64/int64(time.Second) {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(val); err == nil {
+		return max(time.Until(t), 0)
+	}
+	return 0
+}
+
+// readBody reads up to maxBodyBytes from the body, appending an ellipsis if it was truncated.
+func (p *httpProbe) readBody(body io.Reader) string {
+	if p.maxBodyBytes <= 0 {
+		return ""
+	}
+
+	b, err := io.ReadAll(io.LimitReader(body, p.maxBodyBytes+1))
+	if err != nil && len(b) == 0 {
+		return ""
+	}
+
+	truncated := int64(len(b)) > p.maxBodyBytes
+	if truncated {
+		b = b[:p.maxBodyBytes]
+	}
+	s := strings.TrimSpace(strings.ToValidUTF8(string(b), ""))
+	if truncated {
+		s += "..."
+	}
+	return s
+}
@@ -0,0 +1,584 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/dapr/pkg/config"
+)
+
+func TestNewHTTPProbe(t *testing.T) {
+	var (
+		code int
+		body string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(code)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+
+	t.Run("2xx is healthy", func(t *testing.T) {
+		code, body = http.StatusNoContent, ""
+		status, err := mustHTTPProbe(t, srv.URL, WithHTTPClient(srv.Client()))(t.Context())
+		require.NoError(t, err)
+		assert.True(t, status.IsHealthy)
+		assert.Nil(t, status.Reason)
+	})
+
+	t.Run("success reason", func(t *testing.T) {
+		code, body = http.StatusOK, ""
+		status, err := mustHTTPProbe(t, srv.URL, WithHTTPClient(srv.Client()), WithSuccessReason())(t.Context())
+		require.NoError(t, err)
+		assert.True(t, status.IsHealthy)
+		require.NotNil(t, status.Reason)
+		assert.Regexp(t, `^HTTP 200 in \d+(\.\d+)?m?s$`, *status.Reason)
+	})
+
+	t.Run("non-2xx is unhealthy without body by default", func(t *testing.T) {
+		code, body = http.StatusServiceUnavailable, "db down"
+		status, err := mustHTTPProbe(t, srv.URL, WithHTTPClient(srv.Client()))(t.Context())
+		require.NoError(t, err)
+		assert.False(t, status.IsHealthy)
+		require.NotNil(t, status.Reason)
+		assert.Equal(t, "Health check failed with status code: 503", *status.Reason)
+		assert.Equal(t, FailureClassTransient, status.FailureClass)
+		assert.Equal(t, "Check the app logs; endpoint / returned 503", status.RemediationHint)
+	})
+
+	t.Run("not implemented is a permanent failure", func(t *testing.T) {
+		code, body = http.StatusNotImplemented, ""
+		status, err := mustHTTPProbe(t, srv.URL+"/healthz", WithHTTPClient(srv.Client()))(t.Context())
+		require.NoError(t, err)
+		assert.False(t, status.IsHealthy)
+		assert.Equal(t, FailureClassPermanent, status.FailureClass)
+		assert.Equal(t, "Check that the app serves GET requests on the health endpoint /healthz, which returned 501", status.RemediationHint)
+	})
+
+	t.Run("response body is included in the reason", func(t *testing.T) {
+		code, body = http.StatusServiceUnavailable, "db down\n"
+		status, err := mustHTTPProbe(t, srv.URL, WithHTTPClient(srv.Client()), WithResponseBody(100))(t.Context())
+		require.NoError(t, err)
+		require.NotNil(t, status.Reason)
+		assert.Equal(t, "Health check failed with status code: 503: db down", *status.Reason)
+	})
+
+	t.Run("response body is truncated", func(t *testing.T) {
+		code, body = http.StatusInternalServerError, "0123456789"
+		status, err := mustHTTPProbe(t, srv.URL, WithHTTPClient(srv.Client()), WithResponseBody(4))(t.Context())
+		require.NoError(t, err)
+		require.NotNil(t, status.Reason)
+		assert.Equal(t, "Health check failed with status code: 500: 0123...", *status.Reason)
+	})
+
+	t.Run("response body capture is capped", func(t *testing.T) {
+		code, body = http.StatusInternalServerError, strings.Repeat("x", 10<<10)
+		status, err := mustHTTPProbe(t, srv.URL, WithHTTPClient(srv.Client()), WithResponseBody(1<<20))(t.Context())
+		require.NoError(t, err)
+		require.NotNil(t, status.Reason)
+		assert.Len(t, *status.Reason, len("Health check failed with status code: 500: ")+maxHTTPProbeResponseBody+len("..."))
+	})
+
+	t.Run("Retry-After hint is returned", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		t.Cleanup(srv.Close)
+
+		status, err := mustHTTPProbe(t, srv.URL, WithHTTPClient(srv.Client()))(t.Context())
+		require.NoError(t, err)
+		assert.False(t, status.IsHealthy)
+		assert.Equal(t, 30*time.Second, status.RetryAfter)
+	})
+
+	t.Run("network error is unhealthy", func(t *testing.T) {
+		closed := httptest.NewServer(http.NotFoundHandler())
+		closed.Close()
+		status, err := mustHTTPProbe(t, closed.URL)(t.Context())
+		require.NoError(t, err)
+		assert.False(t, status.IsHealthy)
+		require.NotNil(t, status.Reason)
+		assert.Contains(t, *status.Reason, "Network error")
+		assert.Equal(t, "Check that the app is running and listening on "+strings.TrimPrefix(closed.URL, "http://"), status.RemediationHint)
+		var opErr *net.OpError
+		require.ErrorAs(t, status.Cause(), &opErr)
+		assert.Equal(t, "dial", opErr.Op)
+	})
+}
+
+func TestHTTPProbeHealthyStatusCodes(t *testing.T) {
+	var code atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/elsewhere" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if code.Load() == http.StatusFound {
+			w.Header().Set("Location", "/elsewhere")
+		}
+		w.WriteHeader(int(code.Load()))
+	}))
+	t.Cleanup(srv.Close)
+
+	tests := map[string]struct {
+		opts    []HTTPProbeOption
+		code    int
+		healthy bool
+	}{
+		"default is 2xx":             {code: http.StatusNoContent, healthy: true},
+		"default rejects 3xx":        {code: http.StatusNotModified, healthy: false},
+		"explicit code":              {opts: []HTTPProbeOption{WithHealthyStatusCodes(http.StatusOK, http.StatusFound)}, code: http.StatusFound, healthy: true},
+		"explicit code replaces 2xx": {opts: []HTTPProbeOption{WithHealthyStatusCodes(http.StatusOK)}, code: http.StatusNoContent, healthy: false},
+		"range":                      {opts: []HTTPProbeOption{WithHealthyStatusRange(200, 399)}, code: http.StatusNotModified, healthy: true},
+		"outside range":              {opts: []HTTPProbeOption{WithHealthyStatusRange(200, 399)}, code: http.StatusNotFound, healthy: false},
+		"codes and range combined":   {opts: []HTTPProbeOption{WithHealthyStatusRange(200, 299), WithHealthyStatusCodes(http.StatusTooManyRequests)}, code: http.StatusTooManyRequests, healthy: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			code.Store(int32(tc.code))
+			status, err := mustHTTPProbe(t, srv.URL, tc.opts...)(t.Context())
+			require.NoError(t, err)
+			assert.Equal(t, tc.healthy, status.IsHealthy)
+			if !tc.healthy {
+				require.NotNil(t, status.Reason)
+				assert.Contains(t, *status.Reason, strconv.Itoa(tc.code))
+			}
+		})
+	}
+
+	t.Run("invalid ranges", func(t *testing.T) {
+		for _, opt := range []HTTPProbeOption{
+			WithHealthyStatusRange(300, 200),
+			WithHealthyStatusRange(0, 200),
+			WithHealthyStatusCodes(600),
+		} {
+			_, err := NewHTTPProbe(srv.URL, opt)
+			require.Error(t, err)
+		}
+	})
+}
+
+func TestNewDynamicHTTPProbe(t *testing.T) {
+	_, err := NewDynamicHTTPProbe(nil)
+	require.Error(t, err)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	var target atomic.Pointer[string]
+	target.Store(new(string))
+	fn, err := NewDynamicHTTPProbe(func(context.Context) (string, error) {
+		return *target.Load(), nil
+	}, WithHTTPClient(srv.Client()))
+	require.NoError(t, err)
+
+	// Invalid targets are unhealthy
+	status, err := fn(t.Context())
+	require.NoError(t, err)
+	assert.False(t, status.IsHealthy)
+	require.NotNil(t, status.Reason)
+	assert.Contains(t, *status.Reason, "Failed to resolve health probe target")
+
+	target.Store(&srv.URL)
+	status, err = fn(t.Context())
+	require.NoError(t, err)
+	assert.True(t, status.IsHealthy)
+
+	t.Run("resolution error is unhealthy", func(t *testing.T) {
+		resolveErr := errors.New("not bound")
+		fn, err := NewDynamicHTTPProbe(func(context.Context) (string, error) {
+			return "", resolveErr
+		})
+		require.NoError(t, err)
+		status, err := fn(t.Context())
+		require.NoError(t, err)
+		assert.False(t, status.IsHealthy)
+		require.ErrorIs(t, status.Cause(), resolveErr)
+	})
+}
+
+func TestHTTPProbeWarmup(t *testing.T) {
+	var heads, gets atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			heads.Add(1)
+		} else {
+			gets.Add(1)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	p := &httpProbe{
+		url:         srv.URL,
+		client:      srv.Client(),
+		warmupAfter: time.Minute,
+	}
+	fn := p.probe
+
+	// No warmup for the first probe, nor for probes within the idle time
+	for range 2 {
+		status, err := fn(t.Context())
+		require.NoError(t, err)
+		assert.True(t, status.IsHealthy)
+	}
+	assert.Equal(t, int32(0), heads.Load())
+
+	// Simulate an idle period
+	p.lastProbe.Store(time.Now().Add(-2 * time.Minute).UnixNano())
+	status, err := fn(t.Context())
+	require.NoError(t, err)
+	assert.True(t, status.IsHealthy)
+	assert.Equal(t, int32(1), heads.Load())
+	assert.Equal(t, int32(3), gets.Load())
+}
+
+func TestHTTPProbeTokenSource(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	// The token is fetched again for each probe
+	var calls atomic.Int32
+	var tokenErr atomic.Pointer[error]
+	fn := mustHTTPProbe(t, srv.URL, WithHTTPClient(srv.Client()), WithTokenSource(func(context.Context) (string, error) {
+		if err := tokenErr.Load(); err != nil {
+			return "", *err
+		}
+		return "token-" + strconv.Itoa(int(calls.Add(1))), nil
+	}))
+
+	status, err := fn(t.Context())
+	require.NoError(t, err)
+	assert.False(t, status.IsHealthy)
+	assert.Equal(t, "Health check failed with status code: 401", *status.Reason)
+
+	status, err = fn(t.Context())
+	require.NoError(t, err)
+	assert.True(t, status.IsHealthy)
+
+	// Token errors are unhealthy, and have their own reason code
+	fetchErr := errors.New("token expired")
+	tokenErr.Store(&fetchErr)
+	status, err = fn(t.Context())
+	require.NoError(t, err)
+	assert.False(t, status.IsHealthy)
+	assert.Equal(t, ReasonCodeTokenUnavailable, status.Code)
+	require.ErrorIs(t, status.Cause(), fetchErr)
+	assert.Equal(t, "Failed to fetch health probe token: token expired", *status.Reason)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestHTTPProbeTimeouts(t *testing.T) {
+	t.Run("unreachable", func(t *testing.T) {
+		// Reserve a port, then close the listener so connections are refused
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		addr := ln.Addr().String()
+		ln.Close()
+
+		fn := mustHTTPProbe(t, "http://"+addr+"/healthz", WithConnectTimeout(100*time.Millisecond))
+		status, err := fn(t.Context())
+		require.NoError(t, err)
+		assert.False(t, status.IsHealthy)
+		assert.Equal(t, ReasonCodeUnreachable, status.Code)
+	})
+
+	t.Run("slow response", func(t *testing.T) {
+		release := make(chan struct{})
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		t.Cleanup(srv.Close)
+		t.Cleanup(func() { close(release) })
+
+		fn := mustHTTPProbe(t, srv.URL, WithConnectTimeout(time.Second), WithResponseTimeout(20*time.Millisecond))
+		start := time.Now()
+		status, err := fn(t.Context())
+		require.NoError(t, err)
+		assert.Less(t, time.Since(start), 5*time.Second)
+		assert.False(t, status.IsHealthy)
+		assert.Equal(t, ReasonCodeSlowResponse, status.Code)
+	})
+
+	t.Run("within the timeouts", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		t.Cleanup(srv.Close)
+
+		fn := mustHTTPProbe(t, srv.URL, WithConnectTimeout(time.Second), WithResponseTimeout(time.Second))
+		status, err := fn(t.Context())
+		require.NoError(t, err)
+		assert.True(t, status.IsHealthy)
+	})
+}
+
+func TestHTTPProbeDefaultTimeouts(t *testing.T) {
+	// Connections aren't accepted for a while, so the TLS handshake stalls for longer than the transport's usual defaults
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	srv.Listener = &slowListener{Listener: srv.Listener, delay: 6 * time.Second}
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(srv.Certificate())
+	fn := mustHTTPProbe(t, srv.URL, WithTLSConfig(&tls.Config{
+		RootCAs:    roots,
+		MinVersion: tls.VersionTLS12,
+	}))
+
+	// Without explicit timeouts, the probe is only bounded by the probe timeout
+	h := New(config.AppHealthConfig{
+		ProbeTimeout: 10 * time.Second,
+		Threshold:    1,
+	}, fn)
+	t.Cleanup(func() { h.Close() })
+	status, err := h.ManualTrigger(t.Context())
+	require.NoError(t, err)
+	assert.True(t, status.IsHealthy)
+}
+
+// slowListener delays accepting the first connection.
+type slowListener struct {
+	net.Listener
+	delay   time.Duration
+	delayed atomic.Bool
+}
+
+func (l *slowListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err == nil && l.delayed.CompareAndSwap(false, true) {
+		time.Sleep(l.delay)
+	}
+	return conn, err
+}
+
+func mustHTTPProbe(t *testing.T, target string, opts ...HTTPProbeOption) ProbeFunction {
+	t.Helper()
+	fn, err := NewHTTPProbe(target, opts...)
+	require.NoError(t, err)
+	return fn
+}
+
+func TestNewHTTPProbeValidation(t *testing.T) {
+	for _, target := range []string{
+		"",
+		"localhost:3000/healthz",
+		"ftp://localhost/healthz",
+		"http:///healthz",
+		"http://[::1/healthz",
+	} {
+		t.Run(target, func(t *testing.T) {
+			_, err := NewHTTPProbe(target)
+			require.Error(t, err)
+		})
+	}
+
+	t.Run("nil client", func(t *testing.T) {
+		_, err := NewHTTPProbe("http://localhost:3000/healthz", WithHTTPClient(nil))
+		require.Error(t, err)
+	})
+
+	t.Run("invalid proxy", func(t *testing.T) {
+		for _, proxy := range []string{"ftp://proxy:21", "http://", "http://[::1"} {
+			_, err := NewHTTPProbe("http://localhost:3000/healthz", WithProxy(proxy))
+			require.Error(t, err, proxy)
+		}
+	})
+
+	t.Run("proxy with custom client", func(t *testing.T) {
+		_, err := NewHTTPProbe("http://localhost:3000/healthz", WithHTTPClient(&http.Client{}), WithProxy("http://proxy:8080"))
+		require.Error(t, err)
+	})
+
+	t.Run("TLS config with custom client", func(t *testing.T) {
+		_, err := NewHTTPProbe("https://localhost:3000/healthz", WithHTTPClient(&http.Client{}), WithTLSConfig(&tls.Config{}))
+		require.Error(t, err)
+	})
+
+	t.Run("timeouts with custom client", func(t *testing.T) {
+		_, err := NewHTTPProbe("http://localhost:3000/healthz", WithHTTPClient(&http.Client{}), WithConnectTimeout(time.Second))
+		require.Error(t, err)
+	})
+
+	t.Run("negative timeouts", func(t *testing.T) {
+		_, err := NewHTTPProbe("http://localhost:3000/healthz", WithResponseTimeout(-time.Second))
+		require.Error(t, err)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		fn, err := NewHTTPProbe("https://localhost:3000/healthz")
+		require.NoError(t, err)
+		assert.NotNil(t, fn)
+	})
+}
+
+func TestHTTPProbeProxy(t *testing.T) {
+	var proxied atomic.Int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The app's host doesn't resolve, so it can only be reached through the proxy
+		if r.URL.Host != "app.invalid" {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		proxied.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(proxy.Close)
+
+	status, err := mustHTTPProbe(t, "http://app.invalid/healthz", WithProxy(proxy.URL))(t.Context())
+	require.NoError(t, err)
+	assert.True(t, status.IsHealthy)
+	assert.Equal(t, int32(1), proxied.Load())
+
+	t.Run("unreachable proxy is unhealthy", func(t *testing.T) {
+		closed := httptest.NewServer(http.NotFoundHandler())
+		closed.Close()
+		status, err := mustHTTPProbe(t, "http://app.invalid/healthz", WithProxy(closed.URL))(t.Context())
+		require.NoError(t, err)
+		assert.False(t, status.IsHealthy)
+	})
+}
+
+func TestHTTPProbeMTLS(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	srv.TLS = &tls.Config{
+		ClientAuth: tls.RequireAnyClientCert,
+		MinVersion: tls.VersionTLS12,
+	}
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(srv.Certificate())
+	cert := newTestClientCert(t)
+
+	t.Run("without client certificate", func(t *testing.T) {
+		status, err := mustHTTPProbe(t, srv.URL, WithTLSConfig(&tls.Config{
+			RootCAs:    roots,
+			MinVersion: tls.VersionTLS12,
+		}))(t.Context())
+		require.NoError(t, err)
+		assert.False(t, status.IsHealthy)
+	})
+
+	t.Run("with client certificate", func(t *testing.T) {
+		status, err := mustHTTPProbe(t, srv.URL, WithTLSConfig(&tls.Config{
+			RootCAs:      roots,
+			Certificates: []tls.Certificate{cert},
+			MinVersion:   tls.VersionTLS12,
+		}))(t.Context())
+		require.NoError(t, err)
+		assert.True(t, status.IsHealthy)
+	})
+
+	t.Run("with GetClientCertificate", func(t *testing.T) {
+		var calls atomic.Int32
+		status, err := mustHTTPProbe(t, srv.URL,
+			WithTLSConfig(&tls.Config{
+				RootCAs:    roots,
+				MinVersion: tls.VersionTLS12,
+			}),
+			WithGetClientCertificate(func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+				calls.Add(1)
+				return &cert, nil
+			}),
+		)(t.Context())
+		require.NoError(t, err)
+		assert.True(t, status.IsHealthy)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+}
+
+func newTestClientCert(t *testing.T) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "probe"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	return tls.Certificate{
+		Certificate: [][]byte{der},
+		PrivateKey:  key,
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := map[string]struct {
+		val  string
+		want time.Duration
+	}{
+		"empty":        {val: "", want: 0},
+		"seconds":      {val: "120", want: 2 * time.Minute},
+		"zero":         {val: "0", want: 0},
+		"negative":     {val: "-5", want: 0},
+		"overflow":     {val: "99999999999999", want: 0},
+		"invalid":      {val: "soon", want: 0},
+		"date in past": {val: "Wed, 21 Oct 2015 07:28:00 GMT", want: 0},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, parseRetryAfter(tc.val))
+		})
+	}
+
+	t.Run("date in future", func(t *testing.T) {
+		d := parseRetryAfter(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+		assert.InDelta(t, time.Hour, d, float64(2*time.Second))
+	})
+}
@@ -0,0 +1,35 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"errors"
+)
+
+// NewPingProbe returns a ProbeFunction that adapts a function with the common "Ping(ctx) error" signature, such as the ones exposed by database and cache clients.
+// The app is healthy when ping returns nil; otherwise it's unhealthy, with the error in the reason.
+// Returns an error if ping is nil.
+func NewPingProbe(ping func(ctx context.Context) error) (ProbeFunction, error) {
+	if ping == nil {
+		return nil, errors.New("ping function for health probe is nil")
+	}
+
+	return func(ctx context.Context) (*Status, error) {
+		if err := ping(ctx); err != nil {
+			return NewStatusWithCause("Ping failed", err), nil
+		}
+		return NewStatus(true, nil), nil
+	}, nil
+}
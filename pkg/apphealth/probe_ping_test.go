@@ -0,0 +1,47 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPingProbe(t *testing.T) {
+	_, err := NewPingProbe(nil)
+	require.Error(t, err)
+
+	var pingErr error
+	fn, err := NewPingProbe(func(ctx context.Context) error {
+		return pingErr
+	})
+	require.NoError(t, err)
+
+	status, err := fn(t.Context())
+	require.NoError(t, err)
+	assert.True(t, status.IsHealthy)
+	assert.Nil(t, status.Reason)
+
+	pingErr = errors.New("connection refused")
+	status, err = fn(t.Context())
+	require.NoError(t, err)
+	assert.False(t, status.IsHealthy)
+	require.NotNil(t, status.Reason)
+	assert.Equal(t, "Ping failed: connection refused", *status.Reason)
+	require.ErrorIs(t, status.Cause(), pingErr)
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// NewQuorumProbe returns a ProbeFunction that probes each endpoint concurrently, using the probe function returned by build, and reports the app as healthy when at least k endpoints are healthy.
+// Errors returned by the endpoints' probes are counted as failures. The result of each endpoint is included in the status' Details.
+// Returns an error if there are no endpoints, if k is not between 1 and the number of endpoints, or if build returns a nil probe function.
+func NewQuorumProbe(endpoints []string, k int, build func(endpoint string) ProbeFunction) (ProbeFunction, error) {
+	if len(endpoints) == 0 {
+		return nil, errors.New("quorum probe has no endpoints")
+	}
+	if k < 1 || k > len(endpoints) {
+		return nil, fmt.Errorf("quorum for health probe must be between 1 and %d", len(endpoints))
+	}
+	if build == nil {
+		return nil, errors.New("quorum probe has a nil build function")
+	}
+
+	probes := make([]ProbeFunction, len(endpoints))
+	for i, ep := range endpoints {
+		if slices.Contains(endpoints[:i], ep) {
+			return nil, fmt.Errorf("endpoint %q is duplicated in quorum probe", ep)
+		}
+		probes[i] = build(ep)
+		if probes[i] == nil {
+			return nil, fmt.Errorf("probe function for endpoint %q is nil", ep)
+		}
+	}
+	endpoints = slices.Clone(endpoints)
+
+	return func(ctx context.Context) (*Status, error) {
+		results := make([]*Status, len(probes))
+		var wg sync.WaitGroup
+		for i, fn := range probes {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				status, err := fn(ctx)
+				if err != nil {
+					status = NewStatusWithCause("Probe error", err)
+				} else if status == nil {
+					reason := "Probe returned no status"
+					status = NewStatus(false, &reason)
+				}
+				results[i] = status
+			}()
+		}
+		wg.Wait()
+
+		var failed []string
+		details := make(map[string]CheckResult, len(endpoints))
+		for i, ep := range endpoints {
+			details[ep] = CheckResult{
+				IsHealthy: results[i].IsHealthy,
+				Reason:    results[i].Reason,
+			}
+			if !results[i].IsHealthy {
+				failed = append(failed, ep)
+			}
+		}
+
+		var status *Status
+		if healthy := len(endpoints) - len(failed); healthy < k {
+			reason := fmt.Sprintf("Quorum not reached: %d of %d endpoints healthy, %d required; failed endpoints: %s", healthy, len(endpoints), k, strings.Join(failed, ", "))
+			status = NewStatus(false, &reason)
+		} else {
+			status = NewStatus(true, nil)
+		}
+		status.Details = details
+		return status, nil
+	}, nil
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewQuorumProbe(t *testing.T) {
+	var (
+		lock    sync.Mutex
+		healthy = map[string]bool{}
+	)
+	build := func(ep string) ProbeFunction {
+		return func(context.Context) (*Status, error) {
+			lock.Lock()
+			defer lock.Unlock()
+			if ep == "error" {
+				return nil, errors.New("boom")
+			}
+			return NewStatus(healthy[ep], nil), nil
+		}
+	}
+	setHealthy := func(eps ...string) {
+		lock.Lock()
+		defer lock.Unlock()
+		clear(healthy)
+		for _, ep := range eps {
+			healthy[ep] = true
+		}
+	}
+
+	fn, err := NewQuorumProbe([]string{"a", "b", "c", "error"}, 2, build)
+	require.NoError(t, err)
+
+	t.Run("quorum reached", func(t *testing.T) {
+		setHealthy("a", "c")
+		status, err := fn(t.Context())
+		require.NoError(t, err)
+		assert.True(t, status.IsHealthy)
+		assert.Len(t, status.Details, 4)
+		assert.False(t, status.Details["b"].IsHealthy)
+	})
+
+	t.Run("quorum not reached", func(t *testing.T) {
+		setHealthy("b")
+		status, err := fn(t.Context())
+		require.NoError(t, err)
+		assert.False(t, status.IsHealthy)
+		require.NotNil(t, status.Reason)
+		assert.Equal(t, "Quorum not reached: 1 of 4 endpoints healthy, 2 required; failed endpoints: a, c, error", *status.Reason)
+		require.NotNil(t, status.Details["error"].Reason)
+		assert.Equal(t, "Probe error: boom", *status.Details["error"].Reason)
+	})
+
+	t.Run("nil status is a failure", func(t *testing.T) {
+		fn, err := NewQuorumProbe([]string{"a", "b"}, 1, func(ep string) ProbeFunction {
+			return func(context.Context) (*Status, error) {
+				if ep == "a" {
+					return nil, nil
+				}
+				return NewStatus(false, nil), nil
+			}
+		})
+		require.NoError(t, err)
+
+		status, err := fn(t.Context())
+		require.NoError(t, err)
+		assert.False(t, status.IsHealthy)
+		assert.False(t, status.Details["a"].IsHealthy)
+		require.NotNil(t, status.Details["a"].Reason)
+		assert.Equal(t, "Probe returned no status", *status.Details["a"].Reason)
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		_, err := NewQuorumProbe(nil, 1, build)
+		require.Error(t, err)
+		_, err = NewQuorumProbe([]string{"a"}, 0, build)
+		require.Error(t, err)
+		_, err = NewQuorumProbe([]string{"a"}, 2, build)
+		require.Error(t, err)
+		_, err = NewQuorumProbe([]string{"a", "a"}, 1, build)
+		require.Error(t, err)
+		_, err = NewQuorumProbe([]string{"a"}, 1, nil)
+		require.Error(t, err)
+		_, err = NewQuorumProbe([]string{"a"}, 1, func(string) ProbeFunction { return nil })
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	"golang.org/x/net/proxy"
+)
+
+// TCPProbeOption configures a probe created with NewTCPProbe.
+type TCPProbeOption func(*tcpProbe)
+
+type tcpProbe struct {
+	address string
+	// addressFn resolves the address before each probe, if set.
+	addressFn func(ctx context.Context) (string, error)
+	proxy     string
+	dialer    proxy.ContextDialer
+}
+
+// WithTCPProxy connects to the app through the SOCKS5 proxy at proxyURL.
+func WithTCPProxy(proxyURL string) TCPProbeOption {
+	return func(p *tcpProbe) {
+		p.proxy = proxyURL
+	}
+}
+
+// NewTCPProbe returns a ProbeFunction that checks that the app is accepting TCP connections on address, in the "host:port" format.
+// Connection failures, including ones through the proxy, are reported as unhealthy.
+// Returns an error if the address or the proxy URL are not valid.
+func NewTCPProbe(address string, opts ...TCPProbeOption) (ProbeFunction, error) {
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		return nil, fmt.Errorf("invalid health probe address %q: %w", address, err)
+	}
+	return newTCPProbe(&tcpProbe{address: address}, opts)
+}
+
+// NewDynamicTCPProbe is like NewTCPProbe, but the address is resolved by calling addressFn before each probe, for apps whose port is only known at runtime.
+// Errors returned by addressFn, as well as invalid addresses, are reported as unhealthy.
+func NewDynamicTCPProbe(addressFn func(ctx context.Context) (string, error), opts ...TCPProbeOption) (ProbeFunction, error) {
+	if addressFn == nil {
+		return nil, errors.New("address function for health probe is nil")
+	}
+	return newTCPProbe(&tcpProbe{addressFn: addressFn}, opts)
+}
+
+func newTCPProbe(p *tcpProbe, opts []TCPProbeOption) (ProbeFunction, error) {
+	p.dialer = &net.Dialer{}
+	for _, o := range opts {
+		o(p)
+	}
+
+	if p.proxy != "" {
+		pu, err := parseProxyURL(p.proxy, "socks5", "socks5h")
+		if err != nil {
+			return nil, err
+		}
+		d, err := proxy.FromURL(pu, &net.Dialer{})
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL for health probe: %w", err)
+		}
+		cd, ok := d.(proxy.ContextDialer)
+		if !ok {
+			return nil, errors.New("proxy dialer for health probe doesn't support contexts")
+		}
+		p.dialer = cd
+	}
+
+	return p.probe, nil
+}
+
+func (p *tcpProbe) probe(ctx context.Context) (*Status, error) {
+	address := p.address
+	if p.addressFn != nil {
+		var err error
+		address, err = p.addressFn(ctx)
+		if err == nil {
+			_, _, err = net.SplitHostPort(address)
+		}
+		if err != nil {
+			return NewStatusWithCause("Failed to resolve health probe address", err), nil
+		}
+	}
+
+	conn, err := p.dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		status := NewStatusWithCause("Failed to connect to "+address, err)
+		status.RemediationHint = "Check that the app is running and listening on " + address
+		return status, nil
+	}
+	conn.Close()
+
+	return NewStatus(true, nil), nil
+}
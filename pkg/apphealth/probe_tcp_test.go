@@ -0,0 +1,224 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTCPProbe(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	t.Run("listening address is healthy", func(t *testing.T) {
+		fn, err := NewTCPProbe(ln.Addr().String())
+		require.NoError(t, err)
+		status, err := fn(t.Context())
+		require.NoError(t, err)
+		assert.True(t, status.IsHealthy)
+	})
+
+	t.Run("closed port is unhealthy", func(t *testing.T) {
+		closed, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		addr := closed.Addr().String()
+		closed.Close()
+
+		fn, err := NewTCPProbe(addr)
+		require.NoError(t, err)
+		status, err := fn(t.Context())
+		require.NoError(t, err)
+		assert.False(t, status.IsHealthy)
+		require.Error(t, status.Cause())
+		assert.Equal(t, "Check that the app is running and listening on "+addr, status.RemediationHint)
+	})
+
+	t.Run("through a SOCKS5 proxy", func(t *testing.T) {
+		var proxied atomic.Int32
+		proxyAddr := startTestSOCKS5Proxy(t, &proxied)
+
+		fn, err := NewTCPProbe(ln.Addr().String(), WithTCPProxy("socks5://"+proxyAddr))
+		require.NoError(t, err)
+		status, err := fn(t.Context())
+		require.NoError(t, err)
+		assert.True(t, status.IsHealthy)
+		assert.Equal(t, int32(1), proxied.Load())
+	})
+
+	t.Run("unreachable proxy is unhealthy", func(t *testing.T) {
+		closed, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		addr := closed.Addr().String()
+		closed.Close()
+
+		fn, err := NewTCPProbe(ln.Addr().String(), WithTCPProxy("socks5://"+addr))
+		require.NoError(t, err)
+		status, err := fn(t.Context())
+		require.NoError(t, err)
+		assert.False(t, status.IsHealthy)
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		_, err := NewTCPProbe("localhost")
+		require.Error(t, err)
+		_, err = NewTCPProbe("localhost:3000", WithTCPProxy("http://proxy:8080"))
+		require.Error(t, err)
+		_, err = NewTCPProbe("localhost:3000", WithTCPProxy("socks5://"))
+		require.Error(t, err)
+	})
+}
+
+// startTestSOCKS5Proxy starts a minimal SOCKS5 proxy that supports unauthenticated CONNECT requests.
+func startTestSOCKS5Proxy(t *testing.T, proxied *atomic.Int32) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				target, ok := socks5Handshake(conn)
+				if !ok {
+					return
+				}
+				upstream, err := net.Dial("tcp", target)
+				if err != nil {
+					// General failure
+					conn.Write([]byte{5, 1, 0, 1, 0, 0, 0, 0, 0, 0})
+					return
+				}
+				defer upstream.Close()
+				proxied.Add(1)
+				conn.Write([]byte{5, 0, 0, 1, 0, 0, 0, 0, 0, 0})
+			}()
+		}
+	}()
+
+	return ln.Addr().String()
+}
+
+func socks5Handshake(conn net.Conn) (string, bool) {
+	buf := make([]byte, 256)
+	// Greeting: version, number of methods, methods
+	if _, err := io.ReadFull(conn, buf[:2]); err != nil || buf[0] != 5 {
+		return "", false
+	}
+	if _, err := io.ReadFull(conn, buf[:buf[1]]); err != nil {
+		return "", false
+	}
+	if _, err := conn.Write([]byte{5, 0}); err != nil {
+		return "", false
+	}
+
+	// Request: version, command, reserved, address type
+	if _, err := io.ReadFull(conn, buf[:4]); err != nil || buf[1] != 1 {
+		return "", false
+	}
+	var host string
+	switch buf[3] {
+	case 1:
+		if _, err := io.ReadFull(conn, buf[:4]); err != nil {
+			return "", false
+		}
+		host = net.IP(buf[:4]).String()
+	case 3:
+		if _, err := io.ReadFull(conn, buf[:1]); err != nil {
+			return "", false
+		}
+		n := buf[0]
+		if _, err := io.ReadFull(conn, buf[:n]); err != nil {
+			return "", false
+		}
+		host = string(buf[:n])
+	default:
+		return "", false
+	}
+	if _, err := io.ReadFull(conn, buf[:2]); err != nil {
+		return "", false
+	}
+	port := binary.BigEndian.Uint16(buf[:2])
+	return net.JoinHostPort(host, strconv.Itoa(int(port))), true
+}
+
+func TestNewDynamicTCPProbe(t *testing.T) {
+	_, err := NewDynamicTCPProbe(nil)
+	require.Error(t, err)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	var (
+		address    atomic.Pointer[string]
+		resolveErr error
+	)
+	address.Store(new(string))
+	fn, err := NewDynamicTCPProbe(func(context.Context) (string, error) {
+		return *address.Load(), resolveErr
+	})
+	require.NoError(t, err)
+
+	// Not bound yet
+	status, err := fn(t.Context())
+	require.NoError(t, err)
+	assert.False(t, status.IsHealthy)
+
+	// The newly-bound port is picked up without recreating the probe
+	addr := ln.Addr().String()
+	address.Store(&addr)
+	status, err = fn(t.Context())
+	require.NoError(t, err)
+	assert.True(t, status.IsHealthy)
+
+	resolveErr = errors.New("no port yet")
+	status, err = fn(t.Context())
+	require.NoError(t, err)
+	assert.False(t, status.IsHealthy)
+	require.ErrorIs(t, status.Cause(), resolveErr)
+}
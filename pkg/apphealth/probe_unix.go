@@ -0,0 +1,62 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+)
+
+// NewUnixSocketProbe returns a ProbeFunction that checks that the Unix domain socket at path exists and is accepting connections.
+// A missing socket or a refused connection are reported as unhealthy; permission errors are returned as errors, as they indicate a misconfiguration.
+// Returns an error if path is empty.
+func NewUnixSocketProbe(path string) (ProbeFunction, error) {
+	if path == "" {
+		return nil, errors.New("socket path for health probe is empty")
+	}
+
+	return func(ctx context.Context) (*Status, error) {
+		fi, err := os.Stat(path)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			reason := "Socket " + path + " does not exist"
+			status := NewStatus(false, &reason)
+			status.RemediationHint = "Check that the app is running and configured to listen on " + path
+			return status, nil
+		case err != nil:
+			return nil, fmt.Errorf("failed to stat socket %s: %w", path, err)
+		case fi.Mode()&fs.ModeSocket == 0:
+			reason := path + " is not a socket"
+			return NewStatus(false, &reason), nil
+		}
+
+		var d net.Dialer
+		conn, err := d.DialContext(ctx, "unix", path)
+		if err != nil {
+			if errors.Is(err, fs.ErrPermission) {
+				return nil, fmt.Errorf("failed to connect to socket %s: %w", path, err)
+			}
+			status := NewStatusWithCause("Failed to connect to socket "+path, err)
+			status.RemediationHint = "Check that the app is running and listening on " + path
+			return status, nil
+		}
+		conn.Close()
+
+		return NewStatus(true, nil), nil
+	}, nil
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewUnixSocketProbe(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Unix domain sockets are not tested on Windows")
+	}
+
+	// Use a short directory as socket paths are limited in length
+	dir, err := os.MkdirTemp("", "uds")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	t.Run("socket accepting connections is healthy", func(t *testing.T) {
+		path := filepath.Join(dir, "ok.sock")
+		ln, err := net.Listen("unix", path)
+		require.NoError(t, err)
+		t.Cleanup(func() { ln.Close() })
+
+		probe, err := NewUnixSocketProbe(path)
+		require.NoError(t, err)
+		status, err := probe(t.Context())
+		require.NoError(t, err)
+		assert.True(t, status.IsHealthy)
+	})
+
+	t.Run("missing socket is unhealthy", func(t *testing.T) {
+		probe, err := NewUnixSocketProbe(filepath.Join(dir, "missing.sock"))
+		require.NoError(t, err)
+		status, err := probe(t.Context())
+		require.NoError(t, err)
+		assert.False(t, status.IsHealthy)
+		require.NotNil(t, status.Reason)
+		assert.Contains(t, *status.Reason, "does not exist")
+		assert.NotEmpty(t, status.RemediationHint)
+	})
+
+	t.Run("socket not accepting connections is unhealthy", func(t *testing.T) {
+		path := filepath.Join(dir, "closed.sock")
+		ln, err := net.Listen("unix", path)
+		require.NoError(t, err)
+		// Keep the socket file around after closing the listener
+		ln.(*net.UnixListener).SetUnlinkOnClose(false)
+		require.NoError(t, ln.Close())
+
+		probe, err := NewUnixSocketProbe(path)
+		require.NoError(t, err)
+		status, err := probe(t.Context())
+		require.NoError(t, err)
+		assert.False(t, status.IsHealthy)
+	})
+
+	t.Run("empty path fails validation", func(t *testing.T) {
+		_, err := NewUnixSocketProbe("")
+		require.Error(t, err)
+	})
+
+	t.Run("regular file is unhealthy", func(t *testing.T) {
+		path := filepath.Join(dir, "file")
+		require.NoError(t, os.WriteFile(path, []byte("x"), 0o600))
+
+		probe, err := NewUnixSocketProbe(path)
+		require.NoError(t, err)
+		status, err := probe(t.Context())
+		require.NoError(t, err)
+		assert.False(t, status.IsHealthy)
+	})
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// WithDeadline wraps a ProbeFunction so that it runs for at most overall, regardless of the timeout of the context passed to it.
+// The tighter of the caller's deadline and overall applies.
+// If the wrapped probe doesn't return in time, the result is an unhealthy status.
+func WithDeadline(fn ProbeFunction, overall time.Duration) ProbeFunction {
+	return func(parentCtx context.Context) (*Status, error) {
+		ctx, cancel := context.WithTimeout(parentCtx, overall)
+		defer cancel()
+
+		type result struct {
+			status *Status
+			err    error
+		}
+		resCh := make(chan result, 1)
+		go func() {
+			status, err := fn(ctx)
+			resCh <- result{status: status, err: err}
+		}()
+
+		select {
+		case res := <-resCh:
+			return res.status, res.err
+		case <-ctx.Done():
+			if err := parentCtx.Err(); err != nil {
+				return nil, err
+			}
+			reason := fmt.Sprintf("Probe did not complete within the overall deadline of %v", overall)
+			return NewStatus(false, &reason), nil
+		}
+	}
+}
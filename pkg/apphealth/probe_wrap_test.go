@@ -0,0 +1,70 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithDeadline(t *testing.T) {
+	// slowProbe ignores its context to simulate a probe that doesn't honor cancellation
+	slowProbe := func(d time.Duration) ProbeFunction {
+		return func(context.Context) (*Status, error) {
+			time.Sleep(d)
+			return NewStatus(true, nil), nil
+		}
+	}
+
+	t.Run("probe completes within the deadline", func(t *testing.T) {
+		status, err := WithDeadline(slowProbe(0), time.Second)(t.Context())
+		require.NoError(t, err)
+		assert.True(t, status.IsHealthy)
+	})
+
+	t.Run("probe exceeds the deadline", func(t *testing.T) {
+		start := time.Now()
+		status, err := WithDeadline(slowProbe(time.Second), 20*time.Millisecond)(t.Context())
+		require.NoError(t, err)
+		assert.False(t, status.IsHealthy)
+		require.NotNil(t, status.Reason)
+		assert.Contains(t, *status.Reason, "overall deadline")
+		assert.Less(t, time.Since(start), 500*time.Millisecond)
+	})
+
+	t.Run("caller's tighter deadline applies", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+		defer cancel()
+
+		var deadline time.Time
+		_, err := WithDeadline(func(ctx context.Context) (*Status, error) {
+			deadline, _ = ctx.Deadline()
+			return NewStatus(true, nil), nil
+		}, time.Hour)(ctx)
+		require.NoError(t, err)
+		parentDeadline, _ := ctx.Deadline()
+		assert.Equal(t, parentDeadline, deadline)
+	})
+
+	t.Run("caller's cancellation is returned as error", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+		_, err := WithDeadline(slowProbe(time.Second), time.Hour)(ctx)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
@@ -0,0 +1,266 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ProbeSet is a collection of named probes that are evaluated together.
+// Its Probe method is a ProbeFunction that can be passed to New.
+type ProbeSet struct {
+	lock     sync.RWMutex
+	probes   map[string]*namedProbe
+	noProbes NoProbesStatus
+}
+
+// NoProbesStatus is the status reported by a ProbeSet that has no registered probes.
+type NoProbesStatus int
+
+const (
+	// NoProbesHealthy reports an empty set as healthy, since nothing can fail.
+	// This is the default.
+	NoProbesHealthy NoProbesStatus = iota
+	// NoProbesUnhealthy reports an empty set as unhealthy, since nothing proves health.
+	NoProbesUnhealthy
+)
+
+type namedProbe struct {
+	name     string
+	fn       ProbeFunction
+	required bool
+	weight   float64
+	timeout  time.Duration
+	status   *Status
+	latency  time.Duration
+	success  uint64
+	failure  uint64
+}
+
+// ProbeInfo describes a probe registered in a ProbeSet.
+type ProbeInfo struct {
+	Name     string
+	Required bool
+	Weight   float64
+	Timeout  time.Duration
+	// LastStatus is nil if the probe hasn't run yet.
+	LastStatus  *Status
+	LastLatency time.Duration
+	// SuccessCount and FailureCount are the number of healthy and unhealthy results since the probe was added or the set was last reset.
+	SuccessCount uint64
+	FailureCount uint64
+}
+
+// NamedProbeOption configures a probe added to a ProbeSet.
+type NamedProbeOption func(*namedProbe)
+
+// Optional marks a probe as not required: its failures are reported but don't make the set unhealthy.
+func Optional() NamedProbeOption {
+	return func(p *namedProbe) {
+		p.required = false
+	}
+}
+
+// WithWeight sets the weight of a probe, which is reported in ProbeInfo for consumers that aggregate results.
+// The default weight is 1.
+func WithWeight(weight float64) NamedProbeOption {
+	return func(p *namedProbe) {
+		p.weight = weight
+	}
+}
+
+// WithProbeTimeout sets a timeout for a probe, in addition to the timeout of the context passed to the ProbeSet.
+func WithProbeTimeout(timeout time.Duration) NamedProbeOption {
+	return func(p *namedProbe) {
+		p.timeout = timeout
+	}
+}
+
+// NewProbeSet returns a new, empty ProbeSet.
+func NewProbeSet() *ProbeSet {
+	return &ProbeSet{
+		probes: make(map[string]*namedProbe),
+	}
+}
+
+// Add registers a named probe.
+// Probes are required by default.
+func (s *ProbeSet) Add(name string, fn ProbeFunction, opts ...NamedProbeOption) error {
+	if name == "" {
+		return errors.New("probe name is empty")
+	}
+	if fn == nil {
+		return fmt.Errorf("probe %q has a nil probe function", name)
+	}
+
+	p := &namedProbe{
+		name:     name,
+		fn:       fn,
+		required: true,
+		weight:   1,
+	}
+	for _, o := range opts {
+		o(p)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if _, ok := s.probes[name]; ok {
+		return fmt.Errorf("probe %q is already registered", name)
+	}
+	s.probes[name] = p
+	return nil
+}
+
+// Remove unregisters a named probe, returning false if it wasn't registered.
+func (s *ProbeSet) Remove(name string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	_, ok := s.probes[name]
+	delete(s.probes, name)
+	return ok
+}
+
+// SetNoProbesStatus sets the status reported by Probe when no probes are registered, for example after all of them were removed.
+func (s *ProbeSet) SetNoProbesStatus(v NoProbesStatus) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.noProbes = v
+}
+
+// Probe runs all registered probes concurrently and records their results.
+// The set is healthy when all required probes are healthy; the result of each probe is included in the status' Details.
+// Errors returned by individual probes, and nil statuses, are recorded as failures of that probe.
+// If no probes are registered, the status depends on SetNoProbesStatus.
+func (s *ProbeSet) Probe(ctx context.Context) (*Status, error) {
+	s.lock.RLock()
+	probes := make([]*namedProbe, 0, len(s.probes))
+	for _, p := range s.probes {
+		probes = append(probes, p)
+	}
+	noProbes := s.noProbes
+	s.lock.RUnlock()
+
+	if len(probes) == 0 {
+		if noProbes == NoProbesUnhealthy {
+			reason := "No probes registered"
+			return NewStatus(false, &reason), nil
+		}
+		return NewStatus(true, nil), nil
+	}
+
+	results := make([]*Status, len(probes))
+	latencies := make([]time.Duration, len(probes))
+	var wg sync.WaitGroup
+	for i, p := range probes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			probeCtx := ctx
+			if p.timeout > 0 {
+				var cancel context.CancelFunc
+				probeCtx, cancel = context.WithTimeout(ctx, p.timeout)
+				defer cancel()
+			}
+
+			start := time.Now()
+			status, err := p.fn(probeCtx)
+			latencies[i] = time.Since(start)
+			if err != nil {
+				status = NewStatusWithCause("Probe error", err)
+			} else if status == nil {
+				reason := "Probe returned no status"
+				status = NewStatus(false, &reason)
+			}
+			results[i] = status
+		}()
+	}
+	wg.Wait()
+
+	var failed []string
+	details := make(map[string]CheckResult, len(probes))
+	s.lock.Lock()
+	for i, p := range probes {
+		// Skip probes that were removed while running
+		if s.probes[p.name] != p {
+			continue
+		}
+		p.status = results[i]
+		p.latency = latencies[i]
+		if results[i].IsHealthy {
+			p.success++
+		} else {
+			p.failure++
+		}
+		details[p.name] = CheckResult{
+			IsHealthy: results[i].IsHealthy,
+			Reason:    results[i].Reason,
+		}
+		if p.required && !results[i].IsHealthy {
+			failed = append(failed, p.name)
+		}
+	}
+	s.lock.Unlock()
+
+	var status *Status
+	if len(failed) > 0 {
+		slices.Sort(failed)
+		reason := "Failed probes: " + strings.Join(failed, ", ")
+		status = NewStatus(false, &reason)
+	} else {
+		status = NewStatus(true, nil)
+	}
+	status.Details = details
+	return status, nil
+}
+
+// Reset sets the success and failure counters of all registered probes back to zero.
+func (s *ProbeSet) Reset() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, p := range s.probes {
+		p.success = 0
+		p.failure = 0
+	}
+}
+
+// Probes returns information about the registered probes, sorted by name.
+func (s *ProbeSet) Probes() []ProbeInfo {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	res := make([]ProbeInfo, 0, len(s.probes))
+	for _, p := range s.probes {
+		res = append(res, ProbeInfo{
+			Name:         p.name,
+			Required:     p.required,
+			Weight:       p.weight,
+			Timeout:      p.timeout,
+			LastStatus:   p.status,
+			LastLatency:  p.latency,
+			SuccessCount: p.success,
+			FailureCount: p.failure,
+		})
+	}
+	slices.SortFunc(res, func(a, b ProbeInfo) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return res
+}
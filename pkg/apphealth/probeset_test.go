@@ -0,0 +1,257 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func staticProbe(healthy *atomic.Bool) ProbeFunction {
+	return func(context.Context) (*Status, error) {
+		if healthy.Load() {
+			return NewStatus(true, nil), nil
+		}
+		reason := "connection refused"
+		return NewStatus(false, &reason), nil
+	}
+}
+
+func TestProbeSet(t *testing.T) {
+	var dbHealthy, cacheHealthy atomic.Bool
+	s := NewProbeSet()
+	require.NoError(t, s.Add("db", staticProbe(&dbHealthy)))
+	require.NoError(t, s.Add("cache", staticProbe(&cacheHealthy), Optional()))
+
+	t.Run("invalid registrations", func(t *testing.T) {
+		require.Error(t, s.Add("", staticProbe(&dbHealthy)))
+		require.Error(t, s.Add("nil", nil))
+		require.Error(t, s.Add("db", staticProbe(&dbHealthy)))
+	})
+
+	t.Run("required probe failing makes the set unhealthy", func(t *testing.T) {
+		cacheHealthy.Store(true)
+		status, err := s.Probe(t.Context())
+		require.NoError(t, err)
+		assert.False(t, status.IsHealthy)
+		require.NotNil(t, status.Reason)
+		assert.Equal(t, "Failed probes: db", *status.Reason)
+		require.Len(t, status.Details, 2)
+		assert.False(t, status.Details["db"].IsHealthy)
+		assert.True(t, status.Details["cache"].IsHealthy)
+	})
+
+	t.Run("optional probe failing keeps the set healthy", func(t *testing.T) {
+		dbHealthy.Store(true)
+		cacheHealthy.Store(false)
+		status, err := s.Probe(t.Context())
+		require.NoError(t, err)
+		assert.True(t, status.IsHealthy)
+	})
+
+	t.Run("probe errors are failures", func(t *testing.T) {
+		require.NoError(t, s.Add("broken", func(context.Context) (*Status, error) {
+			return nil, errors.New("boom")
+		}))
+		status, err := s.Probe(t.Context())
+		require.NoError(t, err)
+		assert.False(t, status.IsHealthy)
+		assert.True(t, s.Remove("broken"))
+		assert.False(t, s.Remove("broken"))
+	})
+
+	t.Run("nil status is a failure", func(t *testing.T) {
+		require.NoError(t, s.Add("empty", func(context.Context) (*Status, error) {
+			return nil, nil
+		}))
+		status, err := s.Probe(t.Context())
+		require.NoError(t, err)
+		assert.False(t, status.IsHealthy)
+		assert.Equal(t, "Failed probes: empty", *status.Reason)
+		require.NotNil(t, status.Details["empty"].Reason)
+		assert.Equal(t, "Probe returned no status", *status.Details["empty"].Reason)
+		assert.True(t, s.Remove("empty"))
+	})
+}
+
+func TestProbeSetProbes(t *testing.T) {
+	var healthy atomic.Bool
+	healthy.Store(true)
+	s := NewProbeSet()
+	require.NoError(t, s.Add("db", staticProbe(&healthy), WithWeight(2), WithProbeTimeout(time.Second)))
+	require.NoError(t, s.Add("cache", staticProbe(&healthy), Optional()))
+	require.NoError(t, s.Add("slow", func(ctx context.Context) (*Status, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}, WithProbeTimeout(10*time.Millisecond)))
+
+	infos := s.Probes()
+	require.Len(t, infos, 3)
+	assert.Equal(t, "cache", infos[0].Name)
+	assert.False(t, infos[0].Required)
+	assert.InDelta(t, 1, infos[0].Weight, 0)
+	assert.Nil(t, infos[0].LastStatus)
+	assert.Equal(t, "db", infos[1].Name)
+	assert.True(t, infos[1].Required)
+	assert.InDelta(t, 2, infos[1].Weight, 0)
+	assert.Equal(t, time.Second, infos[1].Timeout)
+	assert.Equal(t, "slow", infos[2].Name)
+
+	status, err := s.Probe(t.Context())
+	require.NoError(t, err)
+	assert.False(t, status.IsHealthy)
+	assert.Equal(t, "Failed probes: slow", *status.Reason)
+
+	infos = s.Probes()
+	require.NotNil(t, infos[1].LastStatus)
+	assert.True(t, infos[1].LastStatus.IsHealthy)
+	require.NotNil(t, infos[2].LastStatus)
+	assert.False(t, infos[2].LastStatus.IsHealthy)
+	assert.GreaterOrEqual(t, infos[2].LastLatency, 10*time.Millisecond)
+	assert.Equal(t, uint64(1), infos[1].SuccessCount)
+	assert.Equal(t, uint64(0), infos[1].FailureCount)
+	assert.Equal(t, uint64(0), infos[2].SuccessCount)
+	assert.Equal(t, uint64(1), infos[2].FailureCount)
+
+	healthy.Store(false)
+	_, err = s.Probe(t.Context())
+	require.NoError(t, err)
+	infos = s.Probes()
+	assert.Equal(t, uint64(1), infos[0].SuccessCount)
+	assert.Equal(t, uint64(1), infos[0].FailureCount)
+	assert.Equal(t, uint64(2), infos[2].FailureCount)
+
+	s.Reset()
+	for _, info := range s.Probes() {
+		assert.Zero(t, info.SuccessCount)
+		assert.Zero(t, info.FailureCount)
+		assert.NotNil(t, info.LastStatus)
+	}
+
+	assert.True(t, s.Remove("slow"))
+	infos = s.Probes()
+	require.Len(t, infos, 2)
+	assert.Equal(t, "db", infos[1].Name)
+}
+
+func TestProbeSetNoProbes(t *testing.T) {
+	var healthy atomic.Bool
+	healthy.Store(true)
+	s := NewProbeSet()
+	require.NoError(t, s.Add("db", staticProbe(&healthy)))
+	require.True(t, s.Remove("db"))
+
+	healthz := func(target string) (int, string) {
+		rec := httptest.NewRecorder()
+		s.HealthzHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec.Code, rec.Body.String()
+	}
+
+	t.Run("healthy by default", func(t *testing.T) {
+		status, err := s.Probe(t.Context())
+		require.NoError(t, err)
+		assert.True(t, status.IsHealthy)
+		assert.Empty(t, status.Details)
+
+		code, body := healthz("/healthz")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "ok", body)
+	})
+
+	t.Run("unhealthy", func(t *testing.T) {
+		s.SetNoProbesStatus(NoProbesUnhealthy)
+		status, err := s.Probe(t.Context())
+		require.NoError(t, err)
+		assert.False(t, status.IsHealthy)
+		require.NotNil(t, status.Reason)
+		assert.Equal(t, "No probes registered", *status.Reason)
+
+		code, body := healthz("/healthz")
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, "healthz check failed\n", body)
+		code, body = healthz("/healthz?verbose")
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, "[-]no probes registered\nhealthz check failed\n", body)
+	})
+
+	t.Run("probes added again", func(t *testing.T) {
+		require.NoError(t, s.Add("db", staticProbe(&healthy)))
+		status, err := s.Probe(t.Context())
+		require.NoError(t, err)
+		assert.True(t, status.IsHealthy)
+
+		code, _ := healthz("/healthz")
+		assert.Equal(t, http.StatusOK, code)
+	})
+}
+
+func TestProbeSetHealthzHandler(t *testing.T) {
+	var dbHealthy, cacheHealthy atomic.Bool
+	s := NewProbeSet()
+	require.NoError(t, s.Add("db", staticProbe(&dbHealthy)))
+	require.NoError(t, s.Add("cache", staticProbe(&cacheHealthy), Optional()))
+	handler := s.HealthzHandler()
+
+	get := func(target string) (int, string) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec.Code, rec.Body.String()
+	}
+
+	t.Run("not yet probed", func(t *testing.T) {
+		code, body := get("/healthz?verbose")
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, "[-]cache failed: not yet probed (optional)\n[-]db failed: not yet probed\nhealthz check failed\n", body)
+	})
+
+	dbHealthy.Store(true)
+	_, err := s.Probe(t.Context())
+	require.NoError(t, err)
+
+	t.Run("healthy terse", func(t *testing.T) {
+		code, body := get("/healthz")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "ok", body)
+	})
+
+	t.Run("healthy verbose", func(t *testing.T) {
+		code, body := get("/healthz?verbose")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "[-]cache failed: connection refused (optional)\n[+]db ok\nhealthz check passed\n", body)
+	})
+
+	dbHealthy.Store(false)
+	_, err = s.Probe(t.Context())
+	require.NoError(t, err)
+
+	t.Run("unhealthy terse", func(t *testing.T) {
+		code, body := get("/healthz")
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, "healthz check failed\n", body)
+	})
+
+	t.Run("unhealthy verbose", func(t *testing.T) {
+		code, body := get("/healthz?verbose=1")
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, "[-]cache failed: connection refused (optional)\n[-]db failed: connection refused\nhealthz check failed\n", body)
+	})
+}
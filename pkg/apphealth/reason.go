@@ -0,0 +1,70 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/dapr/dapr/pkg/config"
+)
+
+// maxReasonLength returns the maximum length of reasons, or 0 if they aren't truncated.
+func (h *AppHealth) maxReasonLength() int {
+	switch {
+	case h.config.MaxReasonLength == 0:
+		return config.AppHealthConfigDefaultMaxReasonLength
+	case h.config.MaxReasonLength < 0:
+		return 0
+	default:
+		return h.config.MaxReasonLength
+	}
+}
+
+// truncateReason replaces the reason of the status with a truncated copy if it's longer than MaxReasonLength.
+func (h *AppHealth) truncateReason(status *Status) {
+	maxLen := h.maxReasonLength()
+	if maxLen == 0 || status.Reason == nil || len(*status.Reason) <= maxLen {
+		return
+	}
+	reason := truncateReason(*status.Reason, maxLen)
+	status.Reason = &reason
+}
+
+// limitReason returns s truncated to MaxReasonLength, for messages that are logged without being set as a reason.
+func (h *AppHealth) limitReason(s string) string {
+	if maxLen := h.maxReasonLength(); maxLen > 0 {
+		return truncateReason(s, maxLen)
+	}
+	return s
+}
+
+// truncateReason returns reason cut to at most maxLen bytes, with an ellipsis and the original length appended.
+// The reason is cut at a rune boundary so the result is valid UTF-8 if the input is.
+func truncateReason(reason string, maxLen int) string {
+	if len(reason) <= maxLen {
+		return reason
+	}
+
+	suffix := "... (" + strconv.Itoa(len(reason)) + " bytes)"
+	n := maxLen - len(suffix)
+	if n < 0 {
+		// The limit is too small for the suffix
+		n, suffix = maxLen, ""
+	}
+	for n > 0 && !utf8.RuneStart(reason[n]) {
+		n--
+	}
+	return reason[:n] + suffix
+}
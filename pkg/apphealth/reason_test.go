@@ -0,0 +1,126 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/dapr/pkg/config"
+)
+
+func TestTruncateReason(t *testing.T) {
+	tests := []struct {
+		name   string
+		reason string
+		maxLen int
+		want   string
+	}{
+		{name: "shorter than the limit", reason: "short", maxLen: 10, want: "short"},
+		{name: "exactly the limit", reason: "0123456789", maxLen: 10, want: "0123456789"},
+		{name: "truncated", reason: strings.Repeat("a", 100), maxLen: 30, want: strings.Repeat("a", 15) + "... (100 bytes)"},
+		// "é" is 2 bytes, so the cut at byte 15 falls in the middle of a rune
+		{name: "cut at a rune boundary", reason: strings.Repeat("é", 50), maxLen: 30, want: strings.Repeat("é", 7) + "... (100 bytes)"},
+		{name: "limit smaller than the suffix", reason: strings.Repeat("a", 100), maxLen: 5, want: "aaaaa"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncateReason(tt.reason, tt.maxLen)
+			assert.Equal(t, tt.want, got)
+			assert.LessOrEqual(t, len(got), tt.maxLen)
+			assert.True(t, utf8.ValidString(got))
+		})
+	}
+}
+
+func TestAppHealth_MaxReasonLength(t *testing.T) {
+	// Multi-kilobyte payload, as returned by apps with verbose error pages
+	payload := strings.Repeat("stack frame: ütility.go:42\n", 512)
+
+	t.Run("probe reasons are truncated", func(t *testing.T) {
+		h := New(config.AppHealthConfig{
+			Threshold:       1,
+			MaxReasonLength: 1024,
+		}, func(context.Context) (*Status, error) {
+			return NewStatus(false, &payload), nil
+		})
+		t.Cleanup(func() { h.Close() })
+		h.setResult(t.Context(), NewStatus(true, nil))
+
+		h.doProbe(t.Context())
+		reason := lastReason(t, h)
+		assert.LessOrEqual(t, len(reason), 1024)
+		assert.True(t, utf8.ValidString(reason))
+		assert.True(t, strings.HasSuffix(reason, "... (14336 bytes)"))
+	})
+
+	t.Run("probe errors are truncated", func(t *testing.T) {
+		h := New(config.AppHealthConfig{
+			Threshold:       1,
+			MaxReasonLength: 1024,
+		}, func(context.Context) (*Status, error) {
+			return nil, errors.New(payload)
+		})
+		t.Cleanup(func() { h.Close() })
+		h.setResult(t.Context(), NewStatus(true, nil))
+
+		h.doProbe(t.Context())
+		reason := lastReason(t, h)
+		assert.LessOrEqual(t, len(reason), 1024)
+		assert.True(t, strings.HasPrefix(reason, "Probe error: stack frame"))
+	})
+
+	t.Run("reports are truncated with the default limit", func(t *testing.T) {
+		h := New(config.AppHealthConfig{Threshold: 1}, nil)
+		t.Cleanup(func() { h.Close() })
+		h.setResult(t.Context(), NewStatus(true, nil))
+
+		status := NewStatus(false, &payload)
+		status.Source = StatusSourceReport
+		h.setResult(t.Context(), status)
+		assert.Len(t, lastReason(t, h), config.AppHealthConfigDefaultMaxReasonLength)
+	})
+
+	t.Run("negative limit disables truncation", func(t *testing.T) {
+		h := New(config.AppHealthConfig{
+			Threshold:       1,
+			MaxReasonLength: -1,
+		}, func(context.Context) (*Status, error) {
+			return NewStatus(false, &payload), nil
+		})
+		t.Cleanup(func() { h.Close() })
+		h.setResult(t.Context(), NewStatus(true, nil))
+
+		h.doProbe(t.Context())
+		assert.Equal(t, payload, lastReason(t, h))
+	})
+}
+
+// lastReason returns the reason of the status the app transitioned to most recently.
+func lastReason(t *testing.T, h *AppHealth) string {
+	t.Helper()
+	history := h.History()
+	require.NotEmpty(t, history)
+	to := history[len(history)-1].To
+	require.NotNil(t, to)
+	require.NotNil(t, to.Reason)
+	return *to.Reason
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+)
+
+// startRecovery starts the RecoveryGrace window, after which the app becomes healthy unless a failure is recorded in the meantime.
+// If a window is already running it isn't restarted.
+func (h *AppHealth) startRecovery(ctx context.Context, status *Status, prevSource StatusSource) {
+	h.recoveryLock.Lock()
+	defer h.recoveryLock.Unlock()
+
+	if h.recoveryTimer != nil || h.closed.Load() {
+		return
+	}
+
+	log.Infof("App health check succeeded; waiting %v before reporting the app as healthy", h.config.RecoveryGrace)
+	// Callbacks can be invoked after the loop's context is canceled, so only the context's values are kept
+	ctx = context.WithoutCancel(ctx)
+
+	h.recoveryGen++
+	gen := h.recoveryGen
+	h.wg.Add(1)
+	h.recoveryTimer = h.clock.AfterFunc(h.config.RecoveryGrace, func() {
+		// Run in a separate goroutine since some clocks invoke the function while holding their own locks
+		go h.finishRecovery(ctx, gen, status, prevSource)
+	})
+}
+
+// cancelRecovery stops the RecoveryGrace window, if one is running.
+func (h *AppHealth) cancelRecovery() {
+	h.recoveryLock.Lock()
+	defer h.recoveryLock.Unlock()
+
+	if h.recoveryTimer == nil {
+		return
+	}
+	log.Info("App health check failed during the recovery grace window; the app stays unhealthy")
+	if h.recoveryTimer.Stop() {
+		h.wg.Done()
+	}
+	h.recoveryTimer = nil
+}
+
+// finishRecovery resets the failure count and notifies the healthy transition once the RecoveryGrace window is over.
+func (h *AppHealth) finishRecovery(ctx context.Context, gen uint64, status *Status, prevSource StatusSource) {
+	defer h.wg.Done()
+
+	// Held like setResult does, so the app can't be reported healthy after it was marked as shutting down
+	h.resultLock.RLock()
+	defer h.resultLock.RUnlock()
+	h.recoveryLock.Lock()
+	defer h.recoveryLock.Unlock()
+
+	// The window was canceled, or replaced by a new one
+	if h.recoveryTimer == nil || h.recoveryGen != gen || h.draining.Load() || h.closed.Load() {
+		return
+	}
+	h.recoveryTimer = nil
+
+	prev := h.failureCount.Swap(0)
+	// During a maintenance window the app is already reported as healthy
+	if prev < h.config.Threshold || h.maintenance.Load() {
+		return
+	}
+	id := h.transitionSeq.Add(1)
+	log.Infof("App entered healthy status (transition %d)", id)
+	notified := h.transition(ctx, TransitionEvent{
+		ID:           id,
+		At:           h.clock.Now(),
+		From:         h.statusFor(prev, prevSource),
+		To:           status,
+		FailureCount: 0,
+	})
+	h.traceDecision(status, prev, 0, notified, "transition: healthy after recovery grace")
+}
+
+// recoveryPending returns true if the RecoveryGrace window is running.
+func (h *AppHealth) recoveryPending() bool {
+	h.recoveryLock.Lock()
+	defer h.recoveryLock.Unlock()
+	return h.recoveryTimer != nil
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	"github.com/dapr/dapr/pkg/config"
+)
+
+func TestAppHealth_RecoveryGrace(t *testing.T) {
+	newHealth := func(t *testing.T) (*AppHealth, *clocktesting.FakeClock, chan bool) {
+		h := New(config.AppHealthConfig{
+			ProbeTimeout:  time.Second,
+			Threshold:     1,
+			RecoveryGrace: 5 * time.Second,
+		}, nil)
+		clock := clocktesting.NewFakeClock(time.Now())
+		h.clock = clock
+		t.Cleanup(func() { h.Close() })
+
+		changes := make(chan bool, 4)
+		h.OnHealthChange(func(_ context.Context, status *Status) {
+			changes <- status.IsHealthy
+		})
+		return h, clock, changes
+	}
+
+	assertNoChange := func(t *testing.T, changes chan bool) {
+		t.Helper()
+		select {
+		case v := <-changes:
+			require.Failf(t, "unexpected callback", "healthy: %v", v)
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	t.Run("healthy after the grace window", func(t *testing.T) {
+		h, clock, changes := newHealth(t)
+
+		h.setResult(t.Context(), NewStatus(true, nil))
+		assert.False(t, h.IsHealthy())
+		assert.True(t, h.recoveryPending())
+
+		// Further successes don't restart the window
+		clock.Step(3 * time.Second)
+		h.setResult(t.Context(), NewStatus(true, nil))
+		assertNoChange(t, changes)
+
+		clock.Step(2 * time.Second)
+		select {
+		case v := <-changes:
+			assert.True(t, v)
+		case <-time.After(time.Second):
+			require.Fail(t, "callback not invoked")
+		}
+		assert.True(t, h.IsHealthy())
+		assert.False(t, h.recoveryPending())
+	})
+
+	t.Run("failure within the grace window cancels it", func(t *testing.T) {
+		h, clock, changes := newHealth(t)
+
+		h.setResult(t.Context(), NewStatus(true, nil))
+		clock.Step(2 * time.Second)
+		h.setResult(t.Context(), NewStatus(false, nil))
+		assert.False(t, h.recoveryPending())
+
+		clock.Step(5 * time.Second)
+		assertNoChange(t, changes)
+		assert.False(t, h.IsHealthy())
+	})
+
+	t.Run("failed probe within the grace window cancels it", func(t *testing.T) {
+		h, _, _ := newHealth(t)
+		h.probeFn = func(context.Context) (*Status, error) {
+			return NewStatus(false, nil), nil
+		}
+
+		h.setResult(t.Context(), NewStatus(true, nil))
+		require.True(t, h.recoveryPending())
+		h.doProbe(t.Context())
+		assert.False(t, h.recoveryPending())
+	})
+
+	t.Run("grace window ending while marking the app as shutting down", func(t *testing.T) {
+		h, clock, changes := newHealth(t)
+
+		h.setResult(t.Context(), NewStatus(true, nil))
+		require.True(t, h.recoveryPending())
+
+		// Simulates startDraining, which holds resultLock while it marks the app as unhealthy
+		h.resultLock.Lock()
+		clock.Step(5 * time.Second)
+		time.Sleep(10 * time.Millisecond)
+		h.draining.Store(true)
+		h.resultLock.Unlock()
+
+		assertNoChange(t, changes)
+		assert.False(t, h.IsHealthy())
+	})
+
+	t.Run("close during the grace window", func(t *testing.T) {
+		h, clock, changes := newHealth(t)
+
+		h.setResult(t.Context(), NewStatus(true, nil))
+		require.NoError(t, h.Close())
+		clock.Step(5 * time.Second)
+		assertNoChange(t, changes)
+	})
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// FullHealthReportVersion is the version of the FullHealthReport shape.
+// It's incremented when fields are removed or change meaning; new fields can be added without changing it.
+const FullHealthReportVersion = 1
+
+// fullReportAttempts is the number of times FullReport tries to read a consistent view before returning the last one.
+const fullReportAttempts = 3
+
+// FullHealthReport is a view of everything known about the app's health, meant to be serialized as JSON for debugging and tooling.
+type FullHealthReport struct {
+	Version     int       `json:"version"`
+	GeneratedAt time.Time `json:"generatedAt"`
+	// Status is the current status, as returned by GetStatus.
+	Status       *Status `json:"status"`
+	FailureCount int32   `json:"failureCount"`
+	Threshold    int32   `json:"threshold"`
+	// FailureSamples are the failure counts after the most recent results, from the oldest to the most recent.
+	FailureSamples []int32 `json:"failureSamples"`
+	Trend          Trend   `json:"trend"`
+	Phase          Phase   `json:"phase"`
+	// LastResultAt is the time the last probe result or report was recorded, if any.
+	LastResultAt *time.Time `json:"lastResultAt,omitempty"`
+	// LastReported is the last status reported by the app, if any.
+	LastReported *Status `json:"lastReported,omitempty"`
+	// Details are the individual check results returned by the most recent probe.
+	Details          map[string]CheckResult `json:"details,omitempty"`
+	LastTransitionID uint64                 `json:"lastTransitionId"`
+	// Transitions are the most recent transitions, from the oldest to the most recent.
+	Transitions []FullHealthReportTransition `json:"transitions"`
+}
+
+// FullHealthReportTransition is a transition included in a FullHealthReport.
+type FullHealthReportTransition struct {
+	ID           uint64    `json:"id"`
+	At           time.Time `json:"at"`
+	From         *Status   `json:"from"`
+	To           *Status   `json:"to"`
+	FailureCount int32     `json:"failureCount"`
+}
+
+// FullReport returns a FullHealthReport assembled from the other accessors.
+// The values aren't read atomically, so the report is read again if a result or transition is recorded while it's assembled.
+func (h *AppHealth) FullReport() FullHealthReport {
+	var r FullHealthReport
+	for range fullReportAttempts {
+		seq := h.transitionSeq.Load()
+		lr := h.lastReport.Load()
+
+		r = h.fullReport()
+		if h.transitionSeq.Load() == seq && h.lastReport.Load() == lr {
+			break
+		}
+	}
+	return r
+}
+
+func (h *AppHealth) fullReport() FullHealthReport {
+	snap := h.Snapshot()
+	r := FullHealthReport{
+		Version:          FullHealthReportVersion,
+		GeneratedAt:      h.clock.Now(),
+		Status:           h.GetStatus(),
+		FailureCount:     snap.FailureCount,
+		Threshold:        snap.Threshold,
+		FailureSamples:   h.FailureSamples(),
+		Trend:            h.FailureTrend(),
+		Phase:            h.Phase(),
+		LastReported:     h.LastReported(),
+		Details:          snap.Details,
+		LastTransitionID: snap.LastTransitionID,
+	}
+	if !snap.LastReport.IsZero() {
+		r.LastResultAt = &snap.LastReport
+	}
+
+	history := h.History()
+	r.Transitions = make([]FullHealthReportTransition, len(history))
+	for i, e := range history {
+		r.Transitions[i] = FullHealthReportTransition{
+			ID:           e.ID,
+			At:           e.At,
+			From:         e.From,
+			To:           e.To,
+			FailureCount: e.FailureCount,
+		}
+	}
+	return r
+}
+
+// FullReportHandler returns a HTTP handler that responds with the FullHealthReport serialized as JSON.
+func (h *AppHealth) FullReportHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := json.Marshal(h.FullReport())
+		if err != nil {
+			http.Error(w, "failed to serialize app health report", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.WriteHeader(http.StatusOK)
+		w.Write(b)
+	})
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"errors"
+)
+
+// syncReport is a report received with ReportHealthSync; the resulting status is sent on done.
+type syncReport struct {
+	status *Status
+	done   chan syncReportResult
+}
+
+type syncReportResult struct {
+	status *Status
+	err    error
+}
+
+// ReportHealthSync is like ReportHealth, but it waits for the report to be applied and returns the resulting status of the app.
+// If the probe loop is running, the report is applied by the loop, between probes; if ctx is canceled while waiting, the context's error is returned, but the report may still be applied.
+// Returns an error if status is nil, if reports are disabled with ProbeOnly, if a ReportVerifier is set, or if the object is closed.
+func (h *AppHealth) ReportHealthSync(ctx context.Context, status *Status) (*Status, error) {
+	if status == nil {
+		return nil, errors.New("app health report rejected: status is nil")
+	}
+	if h.config.ProbeOnly {
+		return nil, errors.New("app health reports are disabled: probes only")
+	}
+	if v := h.reportVerifier.Load(); v != nil && *v != nil {
+		log.Warn("Rejecting unsigned app health report because a report verifier is configured")
+		return nil, errors.New("unsigned app health report rejected: a report verifier is configured")
+	}
+	if h.closed.Load() {
+		return nil, ErrClosed
+	}
+
+	if h.config.ReportsCancelProbes {
+		if cancelProbe := h.probeCancel.Load(); cancelProbe != nil {
+			(*cancelProbe)(errProbeSuperseded)
+		}
+	}
+
+	reported := *status
+	reported.Source = StatusSourceReport
+
+	h.runningLock.Lock()
+	if h.closed.Load() {
+		h.runningLock.Unlock()
+		return nil, ErrClosed
+	}
+	if h.running == 0 {
+		defer h.runningLock.Unlock()
+		h.setResult(ctx, &reported)
+		return h.GetStatus(), nil
+	}
+
+	// Queued while holding the lock, so the last loop to exit applies the report if it's not received before
+	req := syncReport{
+		status: &reported,
+		done:   make(chan syncReportResult, 1),
+	}
+	h.syncReports = append(h.syncReports, req)
+	h.runningLock.Unlock()
+	select {
+	case h.syncReportCh <- struct{}{}:
+	default:
+	}
+
+	select {
+	case res := <-req.done:
+		return res.status, res.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// takeSyncReports returns the queued synchronous reports and clears the queue.
+// It must be called with runningLock held.
+func (h *AppHealth) takeSyncReports() []syncReport {
+	reports := h.syncReports
+	h.syncReports = nil
+	return reports
+}
+
+// applySyncReports applies the synchronous reports in order, sending each one the status that results from it.
+func (h *AppHealth) applySyncReports(ctx context.Context, reports []syncReport) {
+	for _, r := range reports {
+		if h.closed.Load() {
+			r.done <- syncReportResult{err: ErrClosed}
+			continue
+		}
+		h.setResult(ctx, r.status)
+		r.done <- syncReportResult{status: h.GetStatus()}
+	}
+}
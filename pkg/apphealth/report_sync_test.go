@@ -0,0 +1,134 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	"github.com/dapr/dapr/pkg/config"
+)
+
+func TestAppHealth_ReportHealthSync(t *testing.T) {
+	newHealth := func(t *testing.T, c config.AppHealthConfig) *AppHealth {
+		c.ProbeInterval = time.Second
+		c.Threshold = 1
+		h := New(c, func(context.Context) (*Status, error) {
+			return NewStatus(true, nil), nil
+		})
+		h.clock = clocktesting.NewFakeClock(time.Now())
+		t.Cleanup(func() { h.Close() })
+		return h
+	}
+
+	t.Run("applied right away when the loop isn't running", func(t *testing.T) {
+		h := newHealth(t, config.AppHealthConfig{})
+		status, err := h.ReportHealthSync(t.Context(), NewStatus(true, nil))
+		require.NoError(t, err)
+		assert.True(t, status.IsHealthy)
+		assert.Equal(t, StatusSourceReport, status.Source)
+
+		status, err = h.ReportHealthSync(t.Context(), NewStatus(false, nil))
+		require.NoError(t, err)
+		assert.False(t, status.IsHealthy)
+	})
+
+	t.Run("applied by the loop when it's running", func(t *testing.T) {
+		h := newHealth(t, config.AppHealthConfig{})
+		ctx, cancel := context.WithCancel(t.Context())
+		var eg errgroup.Group
+		eg.Go(func() error {
+			return h.RunProbes(ctx)
+		})
+		assert.Eventually(t, h.clock.(*clocktesting.FakeClock).HasWaiters, time.Second, time.Microsecond)
+
+		status, err := h.ReportHealthSync(t.Context(), NewStatus(true, nil))
+		require.NoError(t, err)
+		assert.True(t, status.IsHealthy)
+		// No waiting needed: the report was applied when the method returned
+		assert.True(t, h.IsHealthy())
+
+		cancel()
+		require.NoError(t, eg.Wait())
+	})
+
+	t.Run("probe only", func(t *testing.T) {
+		h := newHealth(t, config.AppHealthConfig{ProbeOnly: true})
+		_, err := h.ReportHealthSync(t.Context(), NewStatus(true, nil))
+		require.Error(t, err)
+		assert.False(t, h.IsHealthy())
+	})
+
+	t.Run("report verifier", func(t *testing.T) {
+		h := newHealth(t, config.AppHealthConfig{})
+		h.SetReportVerifier(NewHMACReportVerifier([]byte("secret")))
+		_, err := h.ReportHealthSync(t.Context(), NewStatus(true, nil))
+		require.Error(t, err)
+	})
+
+	t.Run("nil status", func(t *testing.T) {
+		h := newHealth(t, config.AppHealthConfig{})
+		_, err := h.ReportHealthSync(t.Context(), nil)
+		require.Error(t, err)
+		assert.False(t, h.IsHealthy())
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		h := newHealth(t, config.AppHealthConfig{})
+		require.NoError(t, h.Close())
+		_, err := h.ReportHealthSync(t.Context(), NewStatus(true, nil))
+		require.ErrorIs(t, err, ErrClosed)
+	})
+
+	t.Run("pending report is answered on close", func(t *testing.T) {
+		h := newHealth(t, config.AppHealthConfig{})
+		// Pretend a loop is running so the report is queued without being received
+		h.runningLock.Lock()
+		h.running++
+		h.runningLock.Unlock()
+
+		errCh := make(chan error, 1)
+		go func() {
+			_, err := h.ReportHealthSync(t.Context(), NewStatus(true, nil))
+			errCh <- err
+		}()
+		assert.Eventually(t, func() bool {
+			h.runningLock.Lock()
+			defer h.runningLock.Unlock()
+			return len(h.syncReports) == 1
+		}, time.Second, time.Millisecond)
+
+		require.NoError(t, h.Close())
+		require.ErrorIs(t, <-errCh, ErrClosed)
+		assert.False(t, h.IsHealthy())
+	})
+
+	t.Run("context canceled while waiting", func(t *testing.T) {
+		h := newHealth(t, config.AppHealthConfig{})
+		h.runningLock.Lock()
+		h.running++
+		h.runningLock.Unlock()
+
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+		_, err := h.ReportHealthSync(ctx, NewStatus(true, nil))
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
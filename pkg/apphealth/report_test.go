@@ -0,0 +1,76 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	"github.com/dapr/dapr/pkg/config"
+)
+
+func TestAppHealth_FullReport(t *testing.T) {
+	h := New(config.AppHealthConfig{
+		Threshold: 2,
+	}, nil)
+	clock := clocktesting.NewFakeClock(time.Unix(1700000000, 0).UTC())
+	h.clock = clock
+	t.Cleanup(func() { h.Close() })
+
+	h.setResult(t.Context(), NewStatus(true, nil))
+	clock.Step(time.Second)
+	reason := "db down"
+	reported := NewStatus(false, &reason)
+	reported.Source = StatusSourceReport
+	h.setResult(t.Context(), reported)
+
+	rec := httptest.NewRecorder()
+	h.FullReportHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var got FullHealthReport
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	assert.Equal(t, FullHealthReportVersion, got.Version)
+	assert.True(t, got.Status.IsHealthy)
+	assert.Equal(t, int32(1), got.FailureCount)
+	assert.Equal(t, int32(2), got.Threshold)
+	assert.Equal(t, []int32{0, 1}, got.FailureSamples)
+	assert.Equal(t, TrendDegrading, got.Trend)
+	assert.Equal(t, PhaseIdle, got.Phase)
+	require.NotNil(t, got.LastResultAt)
+	assert.True(t, time.Unix(1700000001, 0).Equal(*got.LastResultAt))
+	require.NotNil(t, got.LastReported)
+	assert.Equal(t, "db down", *got.LastReported.Reason)
+	assert.Equal(t, uint64(1), got.LastTransitionID)
+	require.Len(t, got.Transitions, 1)
+	assert.Equal(t, uint64(1), got.Transitions[0].ID)
+	assert.False(t, got.Transitions[0].From.IsHealthy)
+	assert.True(t, got.Transitions[0].To.IsHealthy)
+
+	t.Run("empty report", func(t *testing.T) {
+		r := New(config.AppHealthConfig{Threshold: 1}, nil).FullReport()
+		assert.Nil(t, r.LastResultAt)
+		assert.Nil(t, r.LastReported)
+		assert.Empty(t, r.Transitions)
+		assert.Equal(t, ReasonCodeNotYetProbed, r.Status.Code)
+	})
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ReportVerifier is the signature of the function that checks the signature of a status reported with ReportSignedHealth.
+type ReportVerifier func(status *Status, sig []byte) bool
+
+// SetReportVerifier sets the function that verifies reported statuses.
+// When set, reports without a valid signature, including all the ones received with ReportHealth, are rejected with a warning and an error.
+// Passing nil accepts all reports again.
+func (h *AppHealth) SetReportVerifier(v ReportVerifier) {
+	h.reportVerifier.Store(&v)
+}
+
+// ReportSignedHealth is like ReportHealth, but the status is only accepted if the ReportVerifier accepts sig.
+// If no verifier is set, the signature is ignored.
+func (h *AppHealth) ReportSignedHealth(status *Status, sig []byte) error {
+	if status == nil {
+		return errors.New("app health report rejected: status is nil")
+	}
+	if v := h.reportVerifier.Load(); v != nil && *v != nil && !(*v)(status, sig) {
+		log.Warn("Rejecting app health report with an invalid signature")
+		return errors.New("app health report rejected: invalid signature")
+	}
+	return h.reportHealth(status)
+}
+
+// SignReport returns the HMAC-SHA256 signature of the status with the shared secret, which can be verified by the verifier returned by NewHMACReportVerifier.
+// The signature covers the JSON serialization of the status, excluding its source, and its RetryAfter hint, which isn't serialized otherwise.
+func SignReport(status *Status, secret []byte) ([]byte, error) {
+	if status == nil {
+		return nil, errors.New("cannot sign a nil status")
+	}
+
+	// The source is set by the receiver, so it's not signed
+	signed := *status
+	signed.Source = StatusSourceUnknown
+	payload, err := json.Marshal(struct {
+		*Status
+		RetryAfter time.Duration `json:"retryAfter"`
+	}{
+		Status:     &signed,
+		RetryAfter: signed.RetryAfter,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return mac.Sum(nil), nil
+}
+
+// NewHMACReportVerifier returns a ReportVerifier that accepts statuses signed with SignReport using the same shared secret.
+func NewHMACReportVerifier(secret []byte) ReportVerifier {
+	return func(status *Status, sig []byte) bool {
+		expected, err := SignReport(status, secret)
+		if err != nil {
+			return false
+		}
+		return hmac.Equal(expected, sig)
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/dapr/pkg/config"
+)
+
+func TestAppHealth_ReportVerifier(t *testing.T) {
+	h := New(config.AppHealthConfig{
+		Threshold: 1,
+	}, nil)
+	t.Cleanup(func() { h.Close() })
+
+	// Unsigned reports work when no verifier is set
+	require.NoError(t, h.ReportHealth(NewStatus(true, nil)))
+	assert.True(t, h.IsHealthy())
+
+	secret := []byte("shared secret")
+	h.SetReportVerifier(NewHMACReportVerifier(secret))
+
+	// Unsigned reports are rejected
+	require.Error(t, h.ReportHealth(NewStatus(false, nil)))
+	assert.True(t, h.IsHealthy())
+
+	// Reports signed with another secret are rejected
+	unhealthy := NewStatus(false, nil)
+	sig, err := SignReport(unhealthy, []byte("wrong secret"))
+	require.NoError(t, err)
+	require.Error(t, h.ReportSignedHealth(unhealthy, sig))
+	assert.True(t, h.IsHealthy())
+
+	// Tampered reports are rejected
+	sig, err = SignReport(unhealthy, secret)
+	require.NoError(t, err)
+	tampered := *unhealthy
+	tampered.TimeUnix++
+	require.Error(t, h.ReportSignedHealth(&tampered, sig))
+	assert.True(t, h.IsHealthy())
+
+	// The retry hint is signed too, even though it isn't serialized
+	retry := *unhealthy
+	retry.RetryAfter = time.Minute
+	require.Error(t, h.ReportSignedHealth(&retry, sig))
+	assert.True(t, h.IsHealthy())
+
+	// Nil statuses are rejected rather than verified
+	require.Error(t, h.ReportSignedHealth(nil, sig))
+	_, err = SignReport(nil, secret)
+	require.Error(t, err)
+
+	require.NoError(t, h.ReportSignedHealth(unhealthy, sig))
+	assert.False(t, h.IsHealthy())
+
+	// Removing the verifier accepts unsigned reports again
+	h.SetReportVerifier(nil)
+	h.ReportHealth(NewStatus(true, nil))
+	assert.True(t, h.IsHealthy())
+}
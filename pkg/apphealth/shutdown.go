@@ -0,0 +1,89 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Shutdown marks the app as unhealthy so upstreams stop sending traffic, waits for drainPeriod, and then closes the object.
+// The unhealthy transition is delivered to the change callback before the probe loop stops, bypassing MaxCallbacksPerSecond; probe results and reports received while draining are ignored.
+// If ctx is canceled before the drain period is over, the object is closed right away and the context's error is returned.
+func (h *AppHealth) Shutdown(ctx context.Context, drainPeriod time.Duration) error {
+	if h.closed.Load() {
+		return ErrClosed
+	}
+
+	h.startDraining(ctx)
+
+	var err error
+	if drainPeriod > 0 {
+		log.Infof("Draining for %v before stopping app health probes", drainPeriod)
+		timer := h.clock.NewTimer(drainPeriod)
+		select {
+		case <-timer.C():
+		case <-ctx.Done():
+			timer.Stop()
+			err = ctx.Err()
+		}
+	}
+
+	return errors.Join(err, h.Close())
+}
+
+// startDraining marks the app as shutting down, unless it already is.
+// resultLock is held exclusively so results being applied are finished before the unhealthy transition, and the ones that follow are discarded.
+func (h *AppHealth) startDraining(ctx context.Context) {
+	h.resultLock.Lock()
+	defer h.resultLock.Unlock()
+	if h.draining.CompareAndSwap(false, true) {
+		h.markShuttingDown(ctx)
+	}
+}
+
+// markShuttingDown sets the status to unhealthy and notifies the callback if the app was healthy.
+// The caller must hold resultLock.
+func (h *AppHealth) markShuttingDown(ctx context.Context) {
+	now := h.clock.Now()
+	reason := "App is shutting down"
+	status := NewStatus(false, &reason)
+	status.Code = ReasonCodeShuttingDown
+
+	prev := h.failureCount.Swap(max(h.config.Threshold, 1))
+	// During a maintenance window the app was reported as healthy regardless of the failures
+	maintenance := h.maintenance.Load()
+	if prev >= h.config.Threshold && !maintenance {
+		return
+	}
+	from := h.statusFor(prev, h.Source())
+	if maintenance {
+		from = h.maintenanceStatus()
+	}
+
+	id := h.transitionSeq.Add(1)
+	log.Warnf("App entered un-healthy status (transition %d): %s", id, reason)
+	event := TransitionEvent{
+		ID:           id,
+		At:           now,
+		From:         from,
+		To:           status,
+		FailureCount: max(h.config.Threshold, 1),
+	}
+	h.recordTransition(event)
+	h.transitioned.Store(true)
+	h.dispatchChange(withTransitionID(ctx, id), status)
+	h.dispatchTransition(event)
+}
@@ -0,0 +1,267 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	"github.com/dapr/dapr/pkg/config"
+)
+
+func TestAppHealth_Shutdown(t *testing.T) {
+	newHealth := func(t *testing.T) (*AppHealth, *clocktesting.FakeClock, func() []*Status) {
+		h := New(config.AppHealthConfig{
+			ProbeInterval:         time.Second,
+			Threshold:             1,
+			MaxCallbacksPerSecond: 0.001,
+		}, func(context.Context) (*Status, error) {
+			return NewStatus(true, nil), nil
+		})
+		clock := clocktesting.NewFakeClock(time.Now())
+		h.clock = clock
+
+		var (
+			lock  sync.Mutex
+			calls []*Status
+		)
+		h.OnHealthChange(func(_ context.Context, status *Status) {
+			lock.Lock()
+			defer lock.Unlock()
+			calls = append(calls, status)
+		})
+		h.setResult(t.Context(), NewStatus(true, nil))
+		return h, clock, func() []*Status {
+			lock.Lock()
+			defer lock.Unlock()
+			return calls
+		}
+	}
+
+	t.Run("marks unhealthy and drains before closing", func(t *testing.T) {
+		h, clock, calls := newHealth(t)
+		require.NoError(t, h.StartProbes(t.Context()))
+		assert.Eventually(t, clock.HasWaiters, time.Second, time.Microsecond)
+
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- h.Shutdown(t.Context(), 10*time.Second)
+		}()
+
+		assert.Eventually(t, func() bool {
+			return !h.GetStatus().IsHealthy
+		}, time.Second, time.Microsecond)
+		assert.Eventually(t, func() bool {
+			c := calls()
+			return len(c) == 2 && !c[1].IsHealthy
+		}, time.Second, time.Microsecond)
+		assert.Equal(t, "App is shutting down", *calls()[1].Reason)
+
+		// Results are ignored while draining
+		h.setResult(t.Context(), NewStatus(true, nil))
+		assert.False(t, h.GetStatus().IsHealthy)
+
+		select {
+		case <-errCh:
+			require.Fail(t, "Shutdown returned before the drain period")
+		case <-time.After(10 * time.Millisecond):
+		}
+
+		clock.Step(10 * time.Second)
+		select {
+		case err := <-errCh:
+			require.NoError(t, err)
+		case <-time.After(time.Second):
+			require.Fail(t, "Shutdown didn't return in time")
+		}
+		assert.True(t, h.closed.Load())
+		assert.Equal(t, PhaseStopped, h.Phase())
+	})
+
+	t.Run("context cancellation stops the drain", func(t *testing.T) {
+		h, _, _ := newHealth(t)
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+		require.ErrorIs(t, h.Shutdown(ctx, time.Hour), context.Canceled)
+		assert.True(t, h.closed.Load())
+	})
+
+	t.Run("waits for results being applied", func(t *testing.T) {
+		h, _, _ := newHealth(t)
+
+		// Simulates a setResult that has already passed the draining check
+		h.resultLock.RLock()
+		ctx, cancel := context.WithCancel(t.Context())
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- h.Shutdown(ctx, time.Hour)
+		}()
+		assert.Never(t, h.draining.Load, 50*time.Millisecond, time.Millisecond)
+		assert.True(t, h.IsHealthy())
+		h.resultLock.RUnlock()
+
+		assert.Eventually(t, func() bool {
+			history := h.History()
+			return len(history) > 0 && history[len(history)-1].To.Code == ReasonCodeShuttingDown
+		}, time.Second, time.Microsecond)
+		assert.False(t, h.IsHealthy())
+
+		cancel()
+		require.ErrorIs(t, <-errCh, context.Canceled)
+	})
+
+	t.Run("fails when closed", func(t *testing.T) {
+		h, _, _ := newHealth(t)
+		require.NoError(t, h.Close())
+		require.Error(t, h.Shutdown(t.Context(), 0))
+	})
+}
+
+func TestAppHealth_CloseReleasesSubscribers(t *testing.T) {
+	h := New(config.AppHealthConfig{
+		Threshold:     1,
+		NotifyOnClose: true,
+	}, nil)
+	clock := clocktesting.NewFakeClock(time.Now())
+	h.clock = clock
+
+	var (
+		lock     sync.Mutex
+		statuses []*Status
+	)
+	h.OnHealthChange(func(_ context.Context, status *Status) {
+		lock.Lock()
+		statuses = append(statuses, status)
+		lock.Unlock()
+	})
+	sink := &MemoryAuditSink{}
+	h.SetAuditSink(sink)
+	watchers := []<-chan TransitionEvent{
+		h.Watch(t.Context(), 0),
+		h.Watch(t.Context(), 0),
+	}
+	h.OnHealthyHeartbeat(time.Second, func(context.Context) {})
+
+	h.setResult(t.Context(), NewStatus(true, nil))
+	require.NoError(t, h.Close())
+
+	select {
+	case <-h.Done():
+	default:
+		require.Fail(t, "Done not closed after Close returned")
+	}
+
+	// The callback received the shutdown transition and was unregistered
+	// Callbacks run concurrently, so they may be invoked in any order
+	lock.Lock()
+	require.Len(t, statuses, 2)
+	assert.ElementsMatch(t, []ReasonCode{"", ReasonCodeShuttingDown}, []ReasonCode{statuses[0].Code, statuses[1].Code})
+	lock.Unlock()
+	assert.Nil(t, h.changeCb.Load())
+	require.Len(t, sink.Events(), 2)
+
+	// Watchers received the shutdown transition, then their channel was closed
+	for _, ch := range watchers {
+		var events []TransitionEvent
+		for e := range ch {
+			events = append(events, e)
+		}
+		require.Len(t, events, 2)
+		assert.Equal(t, ReasonCodeShuttingDown, events[1].To.Code)
+	}
+	assert.Empty(t, h.history.watchers)
+
+	// Closing again is a no-op
+	require.NoError(t, h.Close())
+	lock.Lock()
+	assert.Len(t, statuses, 2)
+	lock.Unlock()
+
+	t.Run("without NotifyOnClose subscribers are released without a transition", func(t *testing.T) {
+		h := New(config.AppHealthConfig{
+			Threshold: 1,
+		}, nil)
+		h.setResult(t.Context(), NewStatus(true, nil))
+		ch := h.Watch(t.Context(), 0)
+		require.NoError(t, h.Close())
+		<-h.Done()
+		_, ok := <-ch
+		assert.False(t, ok)
+		assert.True(t, h.IsHealthy())
+	})
+}
+
+func TestAppHealth_CloseStopsResults(t *testing.T) {
+	newHealth := func(t *testing.T) *AppHealth {
+		h := New(config.AppHealthConfig{
+			ProbeInterval: time.Second,
+			ProbeTimeout:  time.Second,
+			Threshold:     1,
+		}, func(context.Context) (*Status, error) {
+			return NewStatus(false, nil), nil
+		})
+		h.clock = clocktesting.NewFakeClock(time.Now())
+		t.Cleanup(func() { h.Close() })
+		return h
+	}
+
+	t.Run("results are discarded after Close returns", func(t *testing.T) {
+		h := newHealth(t)
+		require.NoError(t, h.StartProbes(t.Context()))
+		h.setResult(t.Context(), NewStatus(true, nil))
+		require.NoError(t, h.Close())
+
+		require.ErrorIs(t, h.ReportHealth(NewStatus(false, nil)), ErrClosed)
+		_, err := h.ManualTrigger(t.Context())
+		require.ErrorIs(t, err, ErrClosed)
+
+		// Results already on their way, such as a probe in flight, are discarded too
+		h.doProbe(t.Context())
+		h.setResult(t.Context(), NewStatus(false, nil))
+		assert.True(t, h.IsHealthy())
+		assert.Len(t, h.History(), 1)
+	})
+
+	t.Run("report queued when the loop exits is applied", func(t *testing.T) {
+		h := newHealth(t)
+		h.setResult(t.Context(), NewStatus(true, nil))
+
+		// The loop may exit before receiving the report, which must then be applied rather than lost
+		h.report <- NewStatus(false, nil)
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+		require.NoError(t, h.RunProbes(ctx))
+		assert.False(t, h.IsHealthy())
+		assert.Empty(t, h.report)
+	})
+
+	t.Run("report queued when closed is discarded", func(t *testing.T) {
+		h := newHealth(t)
+		h.setResult(t.Context(), NewStatus(true, nil))
+
+		h.report <- NewStatus(false, nil)
+		require.NoError(t, h.Close())
+		h.runningLock.Lock()
+		h.applyPendingReport()
+		h.runningLock.Unlock()
+		assert.True(t, h.IsHealthy())
+		assert.Empty(t, h.report)
+	})
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"log/slog"
+)
+
+// SetSlogLogger sets a logger that health transitions are mirrored to, with structured attributes, in addition to the Dapr logger.
+// This allows services that standardize on log/slog to collect transitions with the rest of their logs.
+// Passing nil disables it.
+func (h *AppHealth) SetSlogLogger(l *slog.Logger) {
+	h.slogger.Store(l)
+}
+
+// logTransitionSlog logs the transition to the slog logger, if one is set.
+// Healthy transitions are logged at info level and unhealthy ones as warnings, like the Dapr logger does.
+func (h *AppHealth) logTransitionSlog(event TransitionEvent) {
+	l := h.slogger.Load()
+	if l == nil || event.To == nil {
+		return
+	}
+
+	level := slog.LevelInfo
+	if !event.To.IsHealthy {
+		level = slog.LevelWarn
+	}
+	attrs := []slog.Attr{
+		slog.Uint64("transitionId", event.ID),
+		slog.Bool("healthy", event.To.IsHealthy),
+		slog.Int("failureCount", int(event.FailureCount)),
+		slog.String("source", event.To.Source.String()),
+	}
+	switch {
+	case event.To.Reason != nil:
+		attrs = append(attrs, slog.String("reason", *event.To.Reason))
+	case !event.To.IsHealthy:
+		attrs = append(attrs, slog.String("reason", h.formatReason(event.FailureCount)))
+	}
+	if event.To.Code != "" {
+		attrs = append(attrs, slog.String("code", string(event.To.Code)))
+	}
+	l.LogAttrs(context.Background(), level, "App health transition", attrs...)
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/dapr/pkg/config"
+)
+
+func TestAppHealth_SetSlogLogger(t *testing.T) {
+	t.Run("transitions are logged with attributes", func(t *testing.T) {
+		var buf bytes.Buffer
+		h := New(config.AppHealthConfig{Threshold: 2}, nil)
+		t.Cleanup(func() { h.Close() })
+		h.SetSlogLogger(slog.New(slog.NewJSONHandler(&buf, nil)))
+
+		h.setResult(t.Context(), NewStatus(true, nil))
+		reason := "db down"
+		for range 2 {
+			h.setResult(t.Context(), NewStatus(false, &reason))
+		}
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 2)
+
+		var healthy, unhealthy map[string]any
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), &healthy))
+		require.NoError(t, json.Unmarshal([]byte(lines[1]), &unhealthy))
+
+		assert.Equal(t, "INFO", healthy["level"])
+		assert.Equal(t, "App health transition", healthy["msg"])
+		assert.Equal(t, true, healthy["healthy"])
+		assert.InDelta(t, 0, healthy["failureCount"], 0)
+		assert.NotContains(t, healthy, "reason")
+
+		assert.Equal(t, "WARN", unhealthy["level"])
+		assert.Equal(t, false, unhealthy["healthy"])
+		assert.Equal(t, "db down", unhealthy["reason"])
+		assert.InDelta(t, 2, unhealthy["failureCount"], 0)
+		assert.InDelta(t, 2, unhealthy["transitionId"], 0)
+	})
+
+	t.Run("formatted reason when the status has none", func(t *testing.T) {
+		var buf bytes.Buffer
+		h := New(config.AppHealthConfig{Threshold: 1}, nil)
+		t.Cleanup(func() { h.Close() })
+		h.setResult(t.Context(), NewStatus(true, nil))
+		h.SetSlogLogger(slog.New(slog.NewJSONHandler(&buf, nil)))
+
+		h.setResult(t.Context(), NewStatus(false, nil))
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+		assert.Equal(t, "App health check failed 1 times", entry["reason"])
+	})
+
+	t.Run("no-op when not set", func(t *testing.T) {
+		var buf bytes.Buffer
+		h := New(config.AppHealthConfig{Threshold: 1}, nil)
+		t.Cleanup(func() { h.Close() })
+		h.SetSlogLogger(slog.New(slog.NewJSONHandler(&buf, nil)))
+		h.SetSlogLogger(nil)
+
+		h.setResult(t.Context(), NewStatus(true, nil))
+		assert.Empty(t, buf.String())
+	})
+}
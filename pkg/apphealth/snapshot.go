@@ -0,0 +1,164 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"strconv"
+	"time"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// HealthSnapshot is a point-in-time view of the app's health.
+type HealthSnapshot struct {
+	IsHealthy    bool
+	Reason       *string
+	LastReport   time.Time
+	FailureCount int32
+	Threshold    int32
+	Source       StatusSource
+	// Details are the individual check results returned by the most recent probe.
+	Details map[string]CheckResult
+	// LastTransitionID is the ID of the most recent transition, or 0 if there was none.
+	LastTransitionID uint64
+	// CallbackFailures is the number of callbacks that panicked, as returned by CallbackFailureCount.
+	CallbackFailures int64
+	// RemediationHint is the hint returned with the most recent failure, if the app is unhealthy.
+	RemediationHint string
+}
+
+// Snapshot returns the current health of the app.
+func (h *AppHealth) Snapshot() HealthSnapshot {
+	status := h.GetStatus()
+	s := HealthSnapshot{
+		IsHealthy:        status.IsHealthy,
+		Reason:           status.Reason,
+		FailureCount:     h.failureCount.Load(),
+		Threshold:        h.config.Threshold,
+		Source:           status.Source,
+		LastTransitionID: h.transitionSeq.Load(),
+		CallbackFailures: h.callbackFailures.Load(),
+		RemediationHint:  status.RemediationHint,
+	}
+	if lr := h.lastReport.Load(); lr > 0 {
+		s.LastReport = time.UnixMicro(lr)
+	}
+	if d := h.lastDetails.Load(); d != nil {
+		s.Details = maps.Clone(*d)
+	}
+	return s
+}
+
+// ToProto renders the snapshot as a protobuf Struct that can be included in the metadata API response.
+func (s HealthSnapshot) ToProto() *structpb.Struct {
+	fields := map[string]*structpb.Value{
+		"isHealthy":    structpb.NewBoolValue(s.IsHealthy),
+		"failureCount": structpb.NewNumberValue(float64(s.FailureCount)),
+		"threshold":    structpb.NewNumberValue(float64(s.Threshold)),
+		"source":       structpb.NewStringValue(s.Source.String()),
+	}
+	if s.Reason != nil {
+		fields["reason"] = structpb.NewStringValue(*s.Reason)
+	}
+	if !s.LastReport.IsZero() {
+		fields["lastReport"] = structpb.NewStringValue(s.LastReport.UTC().Format(time.RFC3339Nano))
+	}
+	if s.LastTransitionID > 0 {
+		// Rendered as a string since numbers in a Struct are doubles
+		fields["lastTransitionId"] = structpb.NewStringValue(strconv.FormatUint(s.LastTransitionID, 10))
+	}
+	if s.CallbackFailures > 0 {
+		fields["callbackFailures"] = structpb.NewNumberValue(float64(s.CallbackFailures))
+	}
+	if s.RemediationHint != "" {
+		fields["remediationHint"] = structpb.NewStringValue(s.RemediationHint)
+	}
+	if len(s.Details) > 0 {
+		details := make(map[string]*structpb.Value, len(s.Details))
+		for name, d := range s.Details {
+			df := map[string]*structpb.Value{
+				"isHealthy": structpb.NewBoolValue(d.IsHealthy),
+			}
+			if d.Reason != nil {
+				df["reason"] = structpb.NewStringValue(*d.Reason)
+			}
+			details[name] = structpb.NewStructValue(&structpb.Struct{Fields: df})
+		}
+		fields["details"] = structpb.NewStructValue(&structpb.Struct{Fields: details})
+	}
+	return &structpb.Struct{Fields: fields}
+}
+
+// SnapshotFromProto parses a snapshot previously rendered with ToProto.
+func SnapshotFromProto(pb *structpb.Struct) (HealthSnapshot, error) {
+	var s HealthSnapshot
+	if pb == nil {
+		return s, errors.New("health snapshot is nil")
+	}
+
+	fields := pb.GetFields()
+	s.IsHealthy = fields["isHealthy"].GetBoolValue()
+	//nolint:gosec
+	s.FailureCount = int32(fields["failureCount"].GetNumberValue())
+	//nolint:gosec
+	s.Threshold = int32(fields["threshold"].GetNumberValue())
+	if v, ok := fields["reason"]; ok {
+		reason := v.GetStringValue()
+		s.Reason = &reason
+	}
+	if v, ok := fields["source"]; ok {
+		if err := s.Source.UnmarshalText([]byte(v.GetStringValue())); err != nil {
+			return s, fmt.Errorf("invalid source in health snapshot: %w", err)
+		}
+	}
+	if v, ok := fields["lastReport"]; ok {
+		t, err := time.Parse(time.RFC3339Nano, v.GetStringValue())
+		if err != nil {
+			return s, fmt.Errorf("invalid lastReport in health snapshot: %w", err)
+		}
+		s.LastReport = t
+	}
+	if v, ok := fields["lastTransitionId"]; ok {
+		id, err := strconv.ParseUint(v.GetStringValue(), 10, 64)
+		if err != nil {
+			return s, fmt.Errorf("invalid lastTransitionId in health snapshot: %w", err)
+		}
+		s.LastTransitionID = id
+	}
+	if v, ok := fields["callbackFailures"]; ok {
+		s.CallbackFailures = int64(v.GetNumberValue())
+	}
+	if v, ok := fields["remediationHint"]; ok {
+		s.RemediationHint = v.GetStringValue()
+	}
+	if v, ok := fields["details"]; ok {
+		details := v.GetStructValue().GetFields()
+		s.Details = make(map[string]CheckResult, len(details))
+		for name, d := range details {
+			df := d.GetStructValue().GetFields()
+			r := CheckResult{
+				IsHealthy: df["isHealthy"].GetBoolValue(),
+			}
+			if rv, ok := df["reason"]; ok {
+				reason := rv.GetStringValue()
+				r.Reason = &reason
+			}
+			s.Details[name] = r
+		}
+	}
+	return s, nil
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	"github.com/dapr/dapr/pkg/config"
+)
+
+func TestSnapshot(t *testing.T) {
+	h := New(config.AppHealthConfig{
+		Threshold: 2,
+	}, nil)
+	clock := clocktesting.NewFakeClock(time.Unix(1700000000, 0))
+	h.clock = clock
+
+	t.Run("initial snapshot is unhealthy with no report", func(t *testing.T) {
+		s := h.Snapshot()
+		assert.False(t, s.IsHealthy)
+		require.NotNil(t, s.Reason)
+		assert.True(t, s.LastReport.IsZero())
+		assert.Equal(t, int32(2), s.FailureCount)
+		assert.Equal(t, int32(2), s.Threshold)
+		assert.Equal(t, StatusSourceUnknown, s.Source)
+	})
+
+	t.Run("snapshot after healthy result", func(t *testing.T) {
+		h.setResult(t.Context(), NewStatus(true, nil))
+		s := h.Snapshot()
+		assert.True(t, s.IsHealthy)
+		assert.Nil(t, s.Reason)
+		assert.Equal(t, clock.Now().UnixMicro(), s.LastReport.UnixMicro())
+		assert.Equal(t, int32(0), s.FailureCount)
+		assert.Nil(t, s.Details)
+	})
+}
+
+func TestSnapshotDetails(t *testing.T) {
+	reason := "connection refused"
+	details := map[string]CheckResult{
+		"db":    {IsHealthy: false, Reason: &reason},
+		"cache": {IsHealthy: true},
+	}
+	var withDetails atomic.Bool
+	withDetails.Store(true)
+	h := New(config.AppHealthConfig{
+		ProbeTimeout: time.Second,
+		Threshold:    1,
+	}, func(context.Context) (*Status, error) {
+		status := NewStatus(true, nil)
+		if withDetails.Load() {
+			status.Details = details
+		}
+		return status, nil
+	})
+	t.Cleanup(func() { h.Close() })
+
+	_, err := h.ManualTrigger(t.Context())
+	require.NoError(t, err)
+	s := h.Snapshot()
+	assert.True(t, s.IsHealthy)
+	assert.Equal(t, details, s.Details)
+
+	// Details are updated even if the status doesn't change
+	withDetails.Store(false)
+	_, err = h.ManualTrigger(t.Context())
+	require.NoError(t, err)
+	assert.Nil(t, h.Snapshot().Details)
+}
+
+func TestSnapshotRemediationHint(t *testing.T) {
+	h := New(config.AppHealthConfig{Threshold: 1}, nil)
+	t.Cleanup(func() { h.Close() })
+	h.setResult(t.Context(), NewStatus(true, nil))
+
+	status := NewStatus(false, nil)
+	status.RemediationHint = "Check the app logs"
+	h.setResult(t.Context(), status)
+	assert.Equal(t, "Check the app logs", h.Snapshot().RemediationHint)
+	assert.Equal(t, "Check the app logs", h.GetStatus().RemediationHint)
+
+	// The hint is cleared once the app recovers
+	h.setResult(t.Context(), NewStatus(true, nil))
+	assert.Empty(t, h.Snapshot().RemediationHint)
+	h.setResult(t.Context(), NewStatus(false, nil))
+	assert.Empty(t, h.Snapshot().RemediationHint)
+}
+
+func TestHealthSnapshotProtoRoundTrip(t *testing.T) {
+	reason := "App health check failed 3 times"
+	tests := map[string]HealthSnapshot{
+		"unhealthy with reason": {
+			IsHealthy:        false,
+			Reason:           &reason,
+			LastReport:       time.Unix(1700000000, 123456000).UTC(),
+			FailureCount:     3,
+			Threshold:        3,
+			Source:           StatusSourceProbe,
+			CallbackFailures: 2,
+			RemediationHint:  "Check the app logs",
+			Details: map[string]CheckResult{
+				"db":    {IsHealthy: false, Reason: &reason},
+				"cache": {IsHealthy: true},
+			},
+		},
+		"healthy": {
+			IsHealthy:        true,
+			LastReport:       time.Unix(1700000000, 0).UTC(),
+			Threshold:        3,
+			Source:           StatusSourceReport,
+			LastTransitionID: 1<<60 + 1,
+		},
+		"never reported": {
+			FailureCount: 3,
+			Threshold:    3,
+		},
+	}
+
+	for name, snap := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Serialize to the wire format and back to ensure the struct is fully protobuf-serializable
+			b, err := proto.Marshal(snap.ToProto())
+			require.NoError(t, err)
+			var pb structpb.Struct
+			require.NoError(t, proto.Unmarshal(b, &pb))
+
+			got, err := SnapshotFromProto(&pb)
+			require.NoError(t, err)
+			assert.Equal(t, snap, got)
+		})
+	}
+
+	t.Run("nil struct returns error", func(t *testing.T) {
+		_, err := SnapshotFromProto(nil)
+		require.Error(t, err)
+	})
+
+	t.Run("invalid source returns error", func(t *testing.T) {
+		_, err := SnapshotFromProto(&structpb.Struct{Fields: map[string]*structpb.Value{
+			"source": structpb.NewStringValue("carrier pigeon"),
+		}})
+		require.Error(t, err)
+	})
+
+	t.Run("invalid lastReport returns error", func(t *testing.T) {
+		_, err := SnapshotFromProto(&structpb.Struct{Fields: map[string]*structpb.Value{
+			"lastReport": structpb.NewStringValue("not a time"),
+		}})
+		require.Error(t, err)
+	})
+}
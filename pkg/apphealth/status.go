@@ -14,14 +14,143 @@ limitations under the License.
 */
 package apphealth
 
-import "time"
+import (
+	"fmt"
+	"maps"
+	"time"
+)
 
 type Status struct {
+	IsHealthy bool         `json:"ishealthy"`
+	TimeUnix  int64        `json:"timeUnix"`
+	Reason    *string      `json:"reason,omitempty"`
+	Source    StatusSource `json:"source,omitempty"`
+	// Code identifies the reason in a machine-readable way, if set.
+	Code ReasonCode `json:"code,omitempty"`
+	// RemediationHint is optional guidance for operators on how to resolve the failure, such as which logs to check.
+	// Unlike Code it's meant to be read by humans.
+	RemediationHint string `json:"remediationHint,omitempty"`
+	// FailureClass indicates whether the failure is expected to resolve on its own, if known.
+	FailureClass FailureClass `json:"failureClass,omitempty"`
+	// Details are the results of the individual checks performed by the probe, if it reports them.
+	// They are informational: only IsHealthy governs transitions.
+	Details map[string]CheckResult `json:"details,omitempty"`
+
+	// RetryAfter is an optional hint from the probe about when the app expects to be ready.
+	RetryAfter time.Duration `json:"-"`
+
+	// cause is the error that caused the app to be unhealthy, if any.
+	cause error
+}
+
+// ReasonCode identifies why a status has the health it has.
+type ReasonCode string
+
+const (
+	// ReasonCodeNotYetProbed indicates that no probe result or report has been received yet, so the app is not considered healthy.
+	ReasonCodeNotYetProbed ReasonCode = "NotYetProbed"
+	// ReasonCodeSlowResponse indicates that the app responded too slowly: the probe succeeded but took longer than LatencyUnhealthyThreshold, or it timed out waiting for the response.
+	ReasonCodeSlowResponse ReasonCode = "SlowResponse"
+	// ReasonCodeShuttingDown indicates that the app is unhealthy because it's shutting down.
+	ReasonCodeShuttingDown ReasonCode = "ShuttingDown"
+	// ReasonCodeUnreachable indicates that the probe couldn't connect to the app.
+	ReasonCodeUnreachable ReasonCode = "Unreachable"
+	// ReasonCodeMaintenance indicates that the app is reported as healthy because a window of the MaintenanceSchedule is active.
+	ReasonCodeMaintenance ReasonCode = "Maintenance"
+	// ReasonCodeTokenUnavailable indicates that the probe couldn't fetch the token to authenticate with the app, so the app's health is unknown.
+	ReasonCodeTokenUnavailable ReasonCode = "TokenUnavailable"
+)
+
+// CheckResult is the result of an individual check included in a Status.
+type CheckResult struct {
 	IsHealthy bool    `json:"ishealthy"`
-	TimeUnix  int64   `json:"timeUnix"`
 	Reason    *string `json:"reason,omitempty"`
 }
 
+// FailureClass indicates whether a failure is transient or permanent.
+type FailureClass uint8
+
+const (
+	// FailureClassUnknown is used when the probe doesn't classify the failure, and for healthy statuses.
+	FailureClassUnknown FailureClass = iota
+	// FailureClassTransient indicates a failure that is expected to resolve on its own, such as a refused connection while the app restarts.
+	FailureClassTransient
+	// FailureClassPermanent indicates a failure that won't resolve without a change to the app or its configuration, such as a health endpoint that isn't implemented.
+	FailureClassPermanent
+)
+
+var failureClassNames = map[FailureClass]string{
+	FailureClassUnknown:   "unknown",
+	FailureClassTransient: "transient",
+	FailureClassPermanent: "permanent",
+}
+
+func (c FailureClass) String() string {
+	if n, ok := failureClassNames[c]; ok {
+		return n
+	}
+	return failureClassNames[FailureClassUnknown]
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (c FailureClass) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *FailureClass) UnmarshalText(text []byte) error {
+	name := string(text)
+	for v, n := range failureClassNames {
+		if n == name {
+			*c = v
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid failure class: %q", name)
+}
+
+// StatusSource indicates how a health status was determined.
+type StatusSource uint8
+
+const (
+	// StatusSourceUnknown is used when no probe or report has been received yet.
+	StatusSourceUnknown StatusSource = iota
+	// StatusSourceProbe indicates the status comes from a health probe.
+	StatusSourceProbe
+	// StatusSourceReport indicates the status was reported by the app.
+	StatusSourceReport
+)
+
+var statusSourceNames = map[StatusSource]string{
+	StatusSourceUnknown: "unknown",
+	StatusSourceProbe:   "probe",
+	StatusSourceReport:  "report",
+}
+
+func (s StatusSource) String() string {
+	if n, ok := statusSourceNames[s]; ok {
+		return n
+	}
+	return statusSourceNames[StatusSourceUnknown]
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s StatusSource) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *StatusSource) UnmarshalText(text []byte) error {
+	name := string(text)
+	for v, n := range statusSourceNames {
+		if n == name {
+			*s = v
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid status source: %q", name)
+}
+
 // NewStatus returns a default status for the app.
 // This defaults to healthy so subscriptions can start right away,
 // because if there's no health check, then we mark the app as healthy right away so subscriptions can start.
@@ -32,3 +161,46 @@ func NewStatus(isHealthy bool, reason *string) *Status {
 		Reason:    reason,
 	}
 }
+
+// NewStatusWithCause returns an unhealthy status caused by err.
+// The reason is the error's message, prefixed with prefix if not empty; the error itself is available via Cause.
+func NewStatusWithCause(prefix string, err error) *Status {
+	reason := err.Error()
+	if prefix != "" {
+		reason = prefix + ": " + reason
+	}
+	status := NewStatus(false, &reason)
+	status.cause = err
+	return status
+}
+
+// Cause returns the error that caused the app to be unhealthy, or nil if the status wasn't created from an error.
+// This allows inspecting the underlying error with errors.Is and errors.As.
+func (s *Status) Cause() error {
+	return s.cause
+}
+
+// Equal returns true if both statuses have the same health, reason, reason code, failure class, source, retry hint, and details.
+// The time of the status and its cause are not compared.
+func (s *Status) Equal(other *Status) bool {
+	if s == nil || other == nil {
+		return s == other
+	}
+	return s.IsHealthy == other.IsHealthy &&
+		equalReason(s.Reason, other.Reason) &&
+		s.Code == other.Code &&
+		s.RemediationHint == other.RemediationHint &&
+		s.FailureClass == other.FailureClass &&
+		s.Source == other.Source &&
+		s.RetryAfter == other.RetryAfter &&
+		maps.EqualFunc(s.Details, other.Details, func(a, b CheckResult) bool {
+			return a.IsHealthy == b.IsHealthy && equalReason(a.Reason, b.Reason)
+		})
+}
+
+func equalReason(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
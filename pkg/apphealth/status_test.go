@@ -0,0 +1,174 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusSourceText(t *testing.T) {
+	tests := []struct {
+		value StatusSource
+		name  string
+	}{
+		{StatusSourceUnknown, "unknown"},
+		{StatusSourceProbe, "probe"},
+		{StatusSourceReport, "report"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.name, tc.value.String())
+
+			text, err := tc.value.MarshalText()
+			require.NoError(t, err)
+			assert.Equal(t, tc.name, string(text))
+
+			var parsed StatusSource
+			require.NoError(t, parsed.UnmarshalText(text))
+			assert.Equal(t, tc.value, parsed)
+		})
+	}
+
+	t.Run("out of range value renders as unknown", func(t *testing.T) {
+		assert.Equal(t, "unknown", StatusSource(200).String())
+	})
+
+	t.Run("invalid name fails to parse", func(t *testing.T) {
+		var parsed StatusSource
+		require.Error(t, parsed.UnmarshalText([]byte("Probe")))
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		s := NewStatus(true, nil)
+		s.Source = StatusSourceReport
+		b, err := json.Marshal(s)
+		require.NoError(t, err)
+		assert.Contains(t, string(b), `"source":"report"`)
+
+		var parsed Status
+		require.NoError(t, json.Unmarshal(b, &parsed))
+		assert.Equal(t, StatusSourceReport, parsed.Source)
+	})
+}
+
+func TestNewStatusWithCause(t *testing.T) {
+	errRefused := errors.New("connection refused")
+	status := NewStatusWithCause("Network error", fmt.Errorf("dial: %w", errRefused))
+	assert.False(t, status.IsHealthy)
+	require.NotNil(t, status.Reason)
+	assert.Equal(t, "Network error: dial: connection refused", *status.Reason)
+	require.ErrorIs(t, status.Cause(), errRefused)
+
+	status = NewStatusWithCause("", errRefused)
+	assert.Equal(t, "connection refused", *status.Reason)
+
+	// The cause isn't serialized
+	b, err := json.Marshal(status)
+	require.NoError(t, err)
+	assert.NotContains(t, string(b), "cause")
+
+	assert.NoError(t, NewStatus(false, nil).Cause())
+}
+
+func TestStatusDetailsJSON(t *testing.T) {
+	reason := "connection refused"
+	status := NewStatus(true, nil)
+	status.Details = map[string]CheckResult{
+		"db": {IsHealthy: false, Reason: &reason},
+	}
+
+	b, err := json.Marshal(status)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"details":{"db":{"ishealthy":false,"reason":"connection refused"}}`)
+
+	var parsed Status
+	require.NoError(t, json.Unmarshal(b, &parsed))
+	assert.Equal(t, status.Details, parsed.Details)
+
+	b, err = json.Marshal(NewStatus(true, nil))
+	require.NoError(t, err)
+	assert.NotContains(t, string(b), "details")
+}
+
+func TestStatusRemediationHintJSON(t *testing.T) {
+	b, err := json.Marshal(NewStatus(false, nil))
+	require.NoError(t, err)
+	assert.NotContains(t, string(b), "remediationHint")
+
+	status := NewStatus(false, nil)
+	status.RemediationHint = "Check the app logs"
+	b, err = json.Marshal(status)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"remediationHint":"Check the app logs"`)
+}
+
+func TestFailureClassText(t *testing.T) {
+	for _, c := range []FailureClass{FailureClassUnknown, FailureClassTransient, FailureClassPermanent} {
+		text, err := c.MarshalText()
+		require.NoError(t, err)
+		var parsed FailureClass
+		require.NoError(t, parsed.UnmarshalText(text))
+		assert.Equal(t, c, parsed)
+	}
+	assert.Equal(t, "permanent", FailureClassPermanent.String())
+	assert.Equal(t, "unknown", FailureClass(200).String())
+
+	var parsed FailureClass
+	require.Error(t, parsed.UnmarshalText([]byte("fatal")))
+
+	s := NewStatus(false, nil)
+	s.FailureClass = FailureClassTransient
+	b, err := json.Marshal(s)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"failureClass":"transient"`)
+}
+
+func TestStatusEqual(t *testing.T) {
+	reason := func(s string) *string { return &s }
+	withSource := func(s *Status, src StatusSource) *Status {
+		s.Source = src
+		return s
+	}
+
+	tests := map[string]struct {
+		a, b  *Status
+		equal bool
+	}{
+		"both nil":          {nil, nil, true},
+		"one nil":           {NewStatus(true, nil), nil, false},
+		"same":              {NewStatus(false, reason("a")), NewStatus(false, reason("a")), true},
+		"different health":  {NewStatus(true, nil), NewStatus(false, nil), false},
+		"different reason":  {NewStatus(false, reason("a")), NewStatus(false, reason("b")), false},
+		"nil reason":        {NewStatus(false, reason("a")), NewStatus(false, nil), false},
+		"different source":  {withSource(NewStatus(true, nil), StatusSourceProbe), withSource(NewStatus(true, nil), StatusSourceReport), false},
+		"different details": {&Status{Details: map[string]CheckResult{"a": {IsHealthy: true}}}, &Status{Details: map[string]CheckResult{"a": {}}}, false},
+		"same details":      {&Status{Details: map[string]CheckResult{"a": {Reason: reason("x")}}}, &Status{Details: map[string]CheckResult{"a": {Reason: reason("x")}}}, true},
+		"time is ignored":   {&Status{TimeUnix: 1}, &Status{TimeUnix: 2}, true},
+		"different class":   {&Status{FailureClass: FailureClassTransient}, &Status{FailureClass: FailureClassPermanent}, false},
+		"different hint":    {&Status{RemediationHint: "a"}, &Status{RemediationHint: "b"}, false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.equal, tc.a.Equal(tc.b))
+			assert.Equal(t, tc.equal, tc.b.Equal(tc.a))
+		})
+	}
+}
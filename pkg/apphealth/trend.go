@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"fmt"
+	"sync"
+)
+
+// failureSampleCount is the number of failure count samples used to compute the trend.
+const failureSampleCount = 8
+
+// Trend is the direction in which the failure count is moving.
+type Trend uint8
+
+const (
+	// TrendStable indicates the failure count isn't changing, or there aren't enough samples.
+	TrendStable Trend = iota
+	// TrendImproving indicates the failure count is decreasing.
+	TrendImproving
+	// TrendDegrading indicates the failure count is increasing.
+	TrendDegrading
+)
+
+var trendNames = map[Trend]string{
+	TrendStable:    "stable",
+	TrendImproving: "improving",
+	TrendDegrading: "degrading",
+}
+
+func (t Trend) String() string {
+	if n, ok := trendNames[t]; ok {
+		return n
+	}
+	return "unknown"
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t Trend) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *Trend) UnmarshalText(text []byte) error {
+	name := string(text)
+	for v, n := range trendNames {
+		if n == name {
+			*t = v
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid trend: %q", name)
+}
+
+// failureSamples is a ring buffer of the most recent failure counts.
+type failureSamples struct {
+	lock sync.Mutex
+	buf  [failureSampleCount]int32
+	n    int
+	next int
+}
+
+func (s *failureSamples) record(fc int32) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.buf[s.next] = fc
+	s.next = (s.next + 1) % len(s.buf)
+	if s.n < len(s.buf) {
+		s.n++
+	}
+}
+
+// samples returns the samples from the oldest to the most recent.
+func (s *failureSamples) samples() []int32 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	res := make([]int32, s.n)
+	start := s.next - s.n
+	if start < 0 {
+		start += len(s.buf)
+	}
+	for i := range res {
+		res[i] = s.buf[(start+i)%len(s.buf)]
+	}
+	return res
+}
+
+// trend compares the mean of the most recent half of the samples with the mean of the older half.
+func (s *failureSamples) trend() Trend {
+	samples := s.samples()
+	half := len(samples) / 2
+	if half == 0 {
+		return TrendStable
+	}
+
+	// Both windows have the same length, so comparing the sums is equivalent to comparing the means
+	var older, recent int64
+	for i := range half {
+		older += int64(samples[i])
+		recent += int64(samples[len(samples)-half+i])
+	}
+	switch {
+	case recent > older:
+		return TrendDegrading
+	case recent < older:
+		return TrendImproving
+	default:
+		return TrendStable
+	}
+}
+
+// FailureSamples returns the most recent failure counts recorded after each result, from the oldest to the most recent.
+func (h *AppHealth) FailureSamples() []int32 {
+	return h.failureSamples.samples()
+}
+
+// FailureTrend returns whether the failure count is increasing or decreasing over the most recent results.
+// This allows alerting on an app that is about to become unhealthy before the threshold is crossed.
+func (h *AppHealth) FailureTrend() Trend {
+	return h.failureSamples.trend()
+}
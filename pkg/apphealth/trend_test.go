@@ -0,0 +1,88 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/dapr/pkg/config"
+)
+
+func TestTrendText(t *testing.T) {
+	for _, tr := range []Trend{TrendStable, TrendImproving, TrendDegrading} {
+		t.Run(tr.String(), func(t *testing.T) {
+			text, err := tr.MarshalText()
+			require.NoError(t, err)
+
+			var parsed Trend
+			require.NoError(t, parsed.UnmarshalText(text))
+			assert.Equal(t, tr, parsed)
+		})
+	}
+
+	assert.Equal(t, "unknown", Trend(200).String())
+	var parsed Trend
+	require.Error(t, parsed.UnmarshalText([]byte("unknown")))
+}
+
+func TestFailureSamples(t *testing.T) {
+	var s failureSamples
+	assert.Empty(t, s.samples())
+	assert.Equal(t, TrendStable, s.trend())
+
+	s.record(1)
+	assert.Equal(t, TrendStable, s.trend())
+
+	for i := range int32(10) {
+		s.record(i)
+	}
+	// Only the most recent samples are kept
+	assert.Equal(t, []int32{2, 3, 4, 5, 6, 7, 8, 9}, s.samples())
+	assert.Equal(t, TrendDegrading, s.trend())
+
+	for range 4 {
+		s.record(0)
+	}
+	assert.Equal(t, TrendImproving, s.trend())
+
+	for range 4 {
+		s.record(0)
+	}
+	assert.Equal(t, TrendStable, s.trend())
+}
+
+func TestAppHealth_FailureTrend(t *testing.T) {
+	h := New(config.AppHealthConfig{
+		Threshold: 5,
+	}, nil)
+
+	h.setResult(t.Context(), NewStatus(true, nil))
+	assert.Equal(t, TrendStable, h.FailureTrend())
+
+	for range 3 {
+		h.setResult(t.Context(), NewStatus(false, nil))
+	}
+	assert.Equal(t, []int32{0, 1, 2, 3}, h.FailureSamples())
+	assert.Equal(t, TrendDegrading, h.FailureTrend())
+	// The app is still healthy, but getting worse
+	assert.True(t, h.IsHealthy())
+
+	for range 4 {
+		h.setResult(t.Context(), NewStatus(true, nil))
+	}
+	assert.Equal(t, TrendImproving, h.FailureTrend())
+}
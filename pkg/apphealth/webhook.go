@@ -0,0 +1,80 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// webhookTimeout is the timeout for each attempt at delivering a webhook.
+const webhookTimeout = 5 * time.Second
+
+// WebhookCallback returns a ChangeCallback that POSTs the JSON-serialized status to url on each transition.
+// Delivery is retried once; failures are logged and otherwise ignored.
+// If client is nil, http.DefaultClient is used.
+func WebhookCallback(url string, client *http.Client) ChangeCallback {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return func(ctx context.Context, status *Status) {
+		body, err := json.Marshal(status)
+		if err != nil {
+			log.Errorf("Failed to serialize app health status for webhook: %v", err)
+			return
+		}
+
+		for attempt := 1; attempt <= 2; attempt++ {
+			err = postWebhook(ctx, client, url, body)
+			if err == nil {
+				return
+			}
+			if ctx.Err() != nil {
+				break
+			}
+			log.Debugf("App health webhook delivery attempt %d failed: %v", attempt, err)
+		}
+		log.Warnf("Failed to deliver app health webhook to %s: %v", url, err)
+	}
+}
+
+func postWebhook(parentCtx context.Context, client *http.Client, url string, body []byte) error {
+	ctx, cancel := context.WithTimeout(parentCtx, webhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	// Drain before closing
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status code %d", res.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apphealth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookCallback(t *testing.T) {
+	t.Run("posts the status", func(t *testing.T) {
+		var got Status
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		t.Cleanup(srv.Close)
+
+		reason := "boom"
+		status := NewStatus(false, &reason)
+		status.Source = StatusSourceProbe
+		WebhookCallback(srv.URL, srv.Client())(t.Context(), status)
+
+		assert.False(t, got.IsHealthy)
+		require.NotNil(t, got.Reason)
+		assert.Equal(t, "boom", *got.Reason)
+		assert.Equal(t, StatusSourceProbe, got.Source)
+	})
+
+	t.Run("retries once", func(t *testing.T) {
+		var calls atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) == 1 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		t.Cleanup(srv.Close)
+
+		WebhookCallback(srv.URL, srv.Client())(t.Context(), NewStatus(true, nil))
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("gives up after the retry", func(t *testing.T) {
+		var calls atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		t.Cleanup(srv.Close)
+
+		WebhookCallback(srv.URL, nil)(t.Context(), NewStatus(true, nil))
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("invalid URL doesn't panic", func(t *testing.T) {
+		WebhookCallback("://invalid", nil)(t.Context(), NewStatus(true, nil))
+	})
+}
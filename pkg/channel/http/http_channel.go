@@ -323,8 +323,7 @@ func (h *Channel) HealthProbe(ctx context.Context) (*apphealth.Status, error) {
 		// Instead, we just return a failed probe
 		diag.DefaultHTTPMonitoring.AppHealthProbeCompleted(ctx, strconv.Itoa(http.StatusInternalServerError), elapsedMs)
 
-		reason := fmt.Sprintf("Network error: %v", err)
-		return apphealth.NewStatus(false, &reason), nil
+		return apphealth.NewStatusWithCause("Network error", err), nil
 	}
 
 	// Drain before closing
@@ -26,6 +26,16 @@ const (
 	AppHealthConfigDefaultProbeTimeout = 500 * time.Millisecond
 	// AppHealthConfigDefaultThreshold is the default threshold for determining failures in app health checks.
 	AppHealthConfigDefaultThreshold = int32(3)
+	// AppHealthConfigMaxThreshold is the maximum threshold for app health checks.
+	AppHealthConfigMaxThreshold = int32(10_000)
+	// AppHealthConfigDefaultMinProbeInterval is the default minimum interval between app health probes.
+	AppHealthConfigDefaultMinProbeInterval = 100 * time.Millisecond
+	// AppHealthConfigDefaultHistorySize is the default number of app health transitions that are kept in the history.
+	AppHealthConfigDefaultHistorySize = 16
+	// AppHealthConfigDefaultMaxReasonLength is the default maximum length, in bytes, of the reason of app health statuses.
+	AppHealthConfigDefaultMaxReasonLength = 4096
+	// AppHealthConfigDefaultStartupBurstInterval is the default interval between the probes of the startup burst.
+	AppHealthConfigDefaultStartupBurstInterval = 100 * time.Millisecond
 )
 
 // AppHealthConfig is the configuration object for the app health probes.
@@ -33,7 +43,91 @@ type AppHealthConfig struct {
 	ProbeInterval time.Duration
 	ProbeTimeout  time.Duration
 	ProbeOnly     bool
-	Threshold     int32
+	// Threshold is the number of consecutive failures after which the app is unhealthy.
+	// It must be at most AppHealthConfigMaxThreshold.
+	Threshold int32
+	// StartupJitter is the upper bound of the random delay applied before the first probe.
+	StartupJitter time.Duration
+	// MaxCallbacksPerSecond limits how often the health change callback is invoked; if zero, there is no limit.
+	MaxCallbacksPerSecond float64
+	// MaxRetryAfter is the maximum delay that a probe's Retry-After hint can push back the next probe; if zero, hints are ignored.
+	MaxRetryAfter time.Duration
+	// ReportsOverrideProbes controls whether a healthy status reported by the app clears the failures detected by probes.
+	// Defaults to true if nil.
+	ReportsOverrideProbes *bool
+	// MinProbeInterval is the minimum interval between probes; shorter intervals are clamped to this value.
+	// If zero, AppHealthConfigDefaultMinProbeInterval is used; if negative, no clamping is applied.
+	MinProbeInterval time.Duration
+	// FireInitialTransition controls whether the change callback is invoked for the first transition, which establishes the app's initial state.
+	// This doesn't affect the ReadyBarrier, which opens on the first healthy transition regardless.
+	// Defaults to true if nil.
+	FireInitialTransition *bool
+	// CoalesceDuplicateReports makes reports identical to the previous one from the app only refresh the time of the last report, without affecting the failure count.
+	CoalesceDuplicateReports bool
+	// TraceDecisions logs a structured record of every decision made when applying a health result, for debugging flapping.
+	TraceDecisions bool
+	// MaxCallbackGoroutines limits how many change callbacks can run concurrently; if zero, there is no limit.
+	MaxCallbackGoroutines int
+	// DropCallbacksWhenSaturated drops change callbacks when MaxCallbackGoroutines are running, instead of queueing them.
+	// The queue is bounded as well: when it holds 16 callbacks per goroutine, the oldest one is dropped.
+	DropCallbacksWhenSaturated bool
+	// HistorySize is the number of transitions that are kept in the history.
+	// If zero, AppHealthConfigDefaultHistorySize is used; if negative, no history is kept.
+	HistorySize int
+	// IncludeSuccessReason makes the status of a healthy app include the reason of the last successful probe or report, if it had one.
+	IncludeSuccessReason bool
+	// MaxReportAge, if set, makes the app healthy only when probes pass and the app has reported itself healthy within MaxReportAge.
+	// Reports are then tracked separately from probes and don't affect the failure count.
+	MaxReportAge time.Duration
+	// LatencyUnhealthyThreshold, if set, makes probes that succeed but take longer than this count as failures.
+	LatencyUnhealthyThreshold time.Duration
+	// NotifyOnClose makes Close deliver an unhealthy "shutting down" transition to subscribers if the app was healthy, like Shutdown does.
+	NotifyOnClose bool
+	// RecoveryGrace, if set, keeps the app unhealthy for this long after a successful probe or report, including the first one after startup.
+	// The app becomes healthy, and the change callback is invoked, only if no failure is recorded during the window.
+	RecoveryGrace time.Duration
+	// ReportsCancelProbes makes a status reported by the app cancel the probe in flight, if any, discarding its result in favor of the report.
+	ReportsCancelProbes bool
+	// ConfirmationProbes, if set, makes a probe result that would change the app's status trigger this many confirmation probes, ConfirmationInterval apart.
+	// The transition is applied only if the majority of the confirmation probes agree with it.
+	ConfirmationProbes int32
+	// ConfirmationInterval is the interval between confirmation probes.
+	// It's capped so all confirmation probes fit in ProbeInterval; if zero, ProbeInterval divided by ConfirmationProbes is used.
+	ConfirmationInterval time.Duration
+	// PersistInterval is the minimum time between invocations of the persist function after transitions; if zero, it's invoked after every transition.
+	// The latest state is always persisted once the interval is over.
+	PersistInterval time.Duration
+	// LatencySampleSize is the number of recent probe latencies kept to compute percentiles; if zero, latencies aren't tracked.
+	LatencySampleSize int
+	// AliveLogInterval, if set, makes the probe loop log at info level that it's running, with the time since the last probe and the current status, at this interval.
+	AliveLogInterval time.Duration
+	// StartupQuietPeriod, if set, logs failures and unhealthy transitions at debug level rather than as warnings or errors during this period after the probe loop first starts.
+	// It only affects logging: the app is still unhealthy until it's probed successfully.
+	StartupQuietPeriod time.Duration
+	// FailFastOnPermanent makes failures classified as permanent, such as a health endpoint that isn't implemented, make the app unhealthy right away rather than after Threshold failures.
+	FailFastOnPermanent bool
+	// MaxReasonLength is the maximum length, in bytes, of the reasons of statuses; longer reasons are truncated, noting their original length.
+	// If zero, AppHealthConfigDefaultMaxReasonLength is used; if negative, reasons aren't truncated.
+	MaxReasonLength int
+	// ProbeTimeoutHeadroom is the fraction of ProbeInterval, between 0 and 1, that must be left after ProbeTimeout, so a probe that times out is followed by some idle time before the next one.
+	// For example, 0.1 rejects a ProbeTimeout larger than 90% of ProbeInterval. If zero, ProbeTimeout can be as large as ProbeInterval.
+	ProbeTimeoutHeadroom float64
+	// StartupBurst, if set, is the number of probes run StartupBurstInterval apart when the probe loop starts, until the app becomes healthy, before probing every ProbeInterval.
+	// This reduces the time until an app that is already up is considered healthy.
+	StartupBurst int
+	// StartupBurstInterval is the interval between the probes of the startup burst.
+	// If zero, AppHealthConfigDefaultStartupBurstInterval is used; it's capped at ProbeInterval.
+	StartupBurstInterval time.Duration
+	// MaintenanceSchedule lists daily windows during which the app is reported as healthy, for example during nightly batch jobs.
+	// Probes keep running during the windows, but failures don't count towards the threshold; if the app is unhealthy when a window ends, that is reported then.
+	MaintenanceSchedule []AppHealthMaintenanceWindow
+}
+
+// AppHealthMaintenanceWindow is a window that recurs every day, starting at Start after midnight UTC and lasting for Duration.
+// Windows that end after midnight continue into the next day.
+type AppHealthMaintenanceWindow struct {
+	Start    time.Duration
+	Duration time.Duration
 }
 
 // AppConnectionConfig holds the configuration for the app connection.
@@ -17,6 +17,14 @@ const (
 	AppPort string = "APP_PORT"
 	// AppID is the ID of the application.
 	AppID string = "APP_ID"
+	// AppHealthProbeInterval is the interval between app health probes, as a duration string.
+	AppHealthProbeInterval string = "APP_HEALTH_PROBE_INTERVAL"
+	// AppHealthProbeTimeout is the timeout for app health probes, as a duration string.
+	AppHealthProbeTimeout string = "APP_HEALTH_PROBE_TIMEOUT"
+	// AppHealthThreshold is the number of consecutive failures for the app to be considered unhealthy.
+	AppHealthThreshold string = "APP_HEALTH_THRESHOLD"
+	// AppHealthProbeOnly disables health reports from the app, so only probes are used.
+	AppHealthProbeOnly string = "APP_HEALTH_PROBE_ONLY"
 	// OpenTelemetry target URL for OTLP exporter
 	OtlpExporterEndpoint string = "OTEL_EXPORTER_OTLP_ENDPOINT"
 	// OpenTelemetry target URL for OTLP exporter for traces
@@ -33,6 +33,7 @@ var (
 	targetKey           = tag.MustNewKey("target")
 	typeKey             = tag.MustNewKey("type")
 	categoryKey         = tag.MustNewKey("category")
+	outcomeKey          = tag.MustNewKey("outcome")
 )
 
 const (
@@ -79,6 +80,9 @@ type serviceMetrics struct {
 	serviceInvocationResponseReceivedTotal   *stats.Int64Measure
 	serviceInvocationResponseReceivedLatency *stats.Float64Measure
 
+	// App health metrics
+	appHealthProbeOutcomeTotal *stats.Int64Measure
+
 	appID                 string
 	ctx                   context.Context
 	enabled               bool
@@ -207,6 +211,12 @@ func newServiceMetrics() *serviceMetrics {
 			"The latency of service invocation response.",
 			stats.UnitMilliseconds),
 
+		// App health
+		appHealthProbeOutcomeTotal: stats.Int64(
+			"runtime/app_health/probe_outcome_total",
+			"The number of app health probes by raw outcome, before the failure threshold is applied.",
+			stats.UnitDimensionless),
+
 		// TODO: use the correct context for each request
 		ctx:               context.Background(),
 		pendingActorCalls: make(map[string]int32),
@@ -251,6 +261,8 @@ func (s *serviceMetrics) Init(appID string, latencyDistribution *view.Aggregatio
 		diagUtils.NewMeasureView(s.serviceInvocationResponseSentTotal, []tag.Key{appIDKey, destinationAppIDKey, statusKey}, view.Count()),
 		diagUtils.NewMeasureView(s.serviceInvocationResponseReceivedTotal, []tag.Key{appIDKey, sourceAppIDKey, statusKey, typeKey}, view.Count()),
 		diagUtils.NewMeasureView(s.serviceInvocationResponseReceivedLatency, []tag.Key{appIDKey, sourceAppIDKey, statusKey}, latencyDistribution),
+
+		diagUtils.NewMeasureView(s.appHealthProbeOutcomeTotal, []tag.Key{appIDKey, outcomeKey}, view.Count()),
 	)
 }
 
@@ -578,3 +590,13 @@ func (s *serviceMetrics) ServiceInvocationStreamingResponseReceived(sourceAppID
 			s.serviceInvocationResponseReceivedTotal.M(1))
 	}
 }
+
+// AppHealthProbeOutcome records the raw outcome of an app health probe, which is "healthy", "unhealthy", or "error".
+func (s *serviceMetrics) AppHealthProbeOutcome(outcome string) {
+	if s.enabled {
+		stats.RecordWithTags(
+			s.ctx,
+			diagUtils.WithTags(s.appHealthProbeOutcomeTotal.Name(), appIDKey, s.appID, outcomeKey, outcome),
+			s.appHealthProbeOutcomeTotal.M(1))
+	}
+}
@@ -81,6 +81,18 @@ func TestServiceInvocation(t *testing.T) {
 	})
 }
 
+func TestAppHealthProbeOutcome(t *testing.T) {
+	s := servicesMetrics()
+
+	s.AppHealthProbeOutcome("unhealthy")
+
+	viewData, _ := view.RetrieveData("runtime/app_health/probe_outcome_total")
+	v := view.Find("runtime/app_health/probe_outcome_total")
+
+	allTagsPresent(t, v, viewData[0].Tags)
+	RequireTagExist(t, viewData, NewTag(outcomeKey.Name(), "unhealthy"))
+}
+
 func TestSerivceMonitoringInit(t *testing.T) {
 	c := servicesMetrics()
 	assert.True(t, c.enabled)
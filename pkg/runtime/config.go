@@ -455,6 +455,10 @@ func (c *Config) toInternal() (*internalConfig, error) {
 		return nil, errors.New("value for 'health-probe-timeout' must be smaller than 'health-probe-interval'")
 	}
 
+	if c.AppHealthThreshold > int(config.AppHealthConfigMaxThreshold) {
+		return nil, fmt.Errorf("value for 'app-health-threshold' must be at most %d", config.AppHealthConfigMaxThreshold)
+	}
+
 	// Also check to ensure no overflow with int32
 	// TODO: fix types
 	//nolint:gosec
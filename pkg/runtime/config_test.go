@@ -64,6 +64,20 @@ func Test_toInternal(t *testing.T) {
 	assert.Equal(t, "1.1.1.1", intc.appConnectionConfig.ChannelAddress)
 }
 
+func Test_toInternalAppHealthThreshold(t *testing.T) {
+	cfg := defaultTestConfig()
+	cfg.EnableAppHealthCheck = true
+
+	cfg.AppHealthThreshold = int(config.AppHealthConfigMaxThreshold)
+	intc, err := cfg.toInternal()
+	require.NoError(t, err)
+	assert.Equal(t, config.AppHealthConfigMaxThreshold, intc.appConnectionConfig.HealthCheck.Threshold)
+
+	cfg.AppHealthThreshold = int(config.AppHealthConfigMaxThreshold) + 1
+	_, err = cfg.toInternal()
+	require.Error(t, err)
+}
+
 func TestStandaloneWasmStrictSandbox(t *testing.T) {
 	global, err := config.LoadStandaloneConfiguration("../config/testdata/wasm_strict_sandbox.yaml")
 